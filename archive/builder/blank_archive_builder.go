@@ -23,6 +23,7 @@ import (
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
 	"github.com/sacloud/iaas-service-go/internal/ftps"
+	"github.com/sacloud/iaas-service-go/progress"
 	"github.com/sacloud/packages-go/size"
 )
 
@@ -76,7 +77,10 @@ func (b *BlankArchiveBuilder) Build(ctx context.Context, zone string) (*iaas.Arc
 		return nil, err
 	}
 
+	progress.Emit(ctx, &progress.Event{Type: progress.EventCreated, Resource: "Archive", Zone: zone, ID: archive.ID})
+
 	// upload sources via FTPS
+	progress.Emit(ctx, &progress.Event{Type: progress.EventUploading, Resource: "Archive", Zone: zone, ID: archive.ID})
 	ftpsClient, err := ftps.NewClient(ftpServer)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create ftp client: %s", err)
@@ -96,5 +100,10 @@ func (b *BlankArchiveBuilder) Build(ctx context.Context, zone string) (*iaas.Arc
 	}
 
 	// reload
-	return b.Client.Archive.Read(ctx, zone, archive.ID)
+	archive, err = b.Client.Archive.Read(ctx, zone, archive.ID)
+	if err != nil {
+		return nil, err
+	}
+	progress.Emit(ctx, &progress.Event{Type: progress.EventReady, Resource: "Archive", Zone: zone, ID: archive.ID})
+	return archive, nil
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/helper/query"
 	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/progress"
 )
 
 // FromSharedArchiveBuilder 共有アーカイブからアーカイブの作成を行う
@@ -75,13 +76,16 @@ func (b *FromSharedArchiveBuilder) Build(ctx context.Context, zone string) (*iaa
 		return nil, err
 	}
 
+	progress.Emit(ctx, &progress.Event{Type: progress.EventCreated, Resource: "Archive", Zone: zone, ID: archive.ID})
+
 	if b.NoWait {
 		return archive, nil
 	}
 
-	lastState, err := iaas.WaiterForReady(func() (interface{}, error) {
+	waiter := iaas.WaiterForReady(func() (interface{}, error) {
 		return b.Client.Archive.Read(ctx, zone, archive.ID)
-	}).WaitForState(ctx)
+	})
+	lastState, err := progress.WaitForState(ctx, waiter, "Archive", zone, archive.ID)
 
 	var ret *iaas.Archive
 	if lastState != nil {
@@ -0,0 +1,45 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bridge
+
+import (
+	"context"
+	"iter"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-service-go/serviceutil"
+)
+
+func (s *Service) FindIter(req *FindRequest) iter.Seq2[*iaas.Bridge, error] {
+	return s.FindIterWithContext(context.Background(), req)
+}
+
+// FindIterWithContext req.Countをページサイズとして検索結果を遅延取得しながら1件ずつ返す
+//
+// req.Countが0の場合はserviceutil.DefaultPageSizeを利用する
+func (s *Service) FindIterWithContext(ctx context.Context, req *FindRequest) iter.Seq2[*iaas.Bridge, error] {
+	var from, count int
+	if req != nil {
+		from, count = req.From, req.Count
+	}
+	return serviceutil.Paginate(ctx, from, count, func(ctx context.Context, from, count int) ([]*iaas.Bridge, error) {
+		r := &FindRequest{}
+		if req != nil {
+			*r = *req
+		}
+		r.From, r.Count = from, count
+		return s.FindWithContext(ctx, r)
+	})
+}
@@ -14,7 +14,11 @@
 
 package certificateauthority
 
-import "github.com/sacloud/iaas-api-go"
+import (
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/certificateauthority/builder"
+)
 
 // Service provides a high-level API of for CertificateAuthority
 type Service struct {
@@ -25,3 +29,13 @@ type Service struct {
 func New(caller iaas.APICaller) *Service {
 	return &Service{caller: caller}
 }
+
+var (
+	_ service.Creator[*CreateRequest, *builder.CertificateAuthority] = (*Service)(nil)
+	_ service.Reader[*ReadRequest, *builder.CertificateAuthority]    = (*Service)(nil)
+	_ service.Updater[*UpdateRequest, *builder.CertificateAuthority] = (*Service)(nil)
+	_ service.Deleter[*DeleteRequest]                                = (*Service)(nil)
+	_ service.Finder[*FindRequest, *iaas.CertificateAuthority]       = (*Service)(nil)
+	_ service.FindIterator[*FindRequest, *iaas.CertificateAuthority] = (*Service)(nil)
+	_ service.Applier[*ApplyRequest, *builder.CertificateAuthority]  = (*Service)(nil)
+)
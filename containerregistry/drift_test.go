@@ -0,0 +1,40 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containerregistry
+
+import (
+	"testing"
+
+	"github.com/sacloud/iaas-service-go/containerregistry/builder"
+	"github.com/sacloud/iaas-service-go/drift"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerRegistryService_driftIgnoreFields(t *testing.T) {
+	current := &ApplyRequest{
+		Users:        []*builder.User{{UserName: "user1"}},
+		SettingsHash: "settings-hash",
+	}
+	// パスワードはAPIから参照できないため比較しない
+	desired := &ApplyRequest{
+		Users: []*builder.User{{UserName: "user1", Password: "password"}},
+	}
+	require.Empty(t, drift.Compare(current, desired, driftIgnoreFields...))
+
+	desired.Users[0].UserName = "user2"
+	diffs := drift.Compare(current, desired, driftIgnoreFields...)
+	require.Len(t, diffs, 1)
+	require.Equal(t, "Users[0].UserName", diffs[0].Path)
+}
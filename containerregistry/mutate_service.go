@@ -0,0 +1,44 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containerregistry
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-service-go/serviceutil"
+)
+
+func (s *Service) Mutate(req *MutateRequest) (*iaas.ContainerRegistry, error) {
+	return s.MutateWithContext(context.Background(), req)
+}
+
+func (s *Service) MutateWithContext(ctx context.Context, req *MutateRequest) (*iaas.ContainerRegistry, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	read := func(ctx context.Context) (*ApplyRequest, error) {
+		current, err := iaas.NewContainerRegistryOp(s.caller).Read(ctx, req.ID)
+		if err != nil {
+			return nil, err
+		}
+		return (&UpdateRequest{ID: req.ID, SettingsHash: current.SettingsHash}).ApplyRequest(ctx, s.caller)
+	}
+	update := func(ctx context.Context, current *ApplyRequest) (*iaas.ContainerRegistry, error) {
+		return s.ApplyWithContext(ctx, current)
+	}
+	return serviceutil.UpdateWithMutator(ctx, req.MaxRetries, read, req.Mutator, update)
+}
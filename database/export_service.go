@@ -0,0 +1,50 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-service-go/serviceutil"
+)
+
+// Export 既存リソースをIDを保持したApplyRequestとしてJSONまたはYAML形式で出力する
+func (s *Service) Export(req *ExportRequest) ([]byte, error) {
+	return s.ExportWithContext(context.Background(), req)
+}
+
+// ExportWithContext 既存リソースをIDを保持したApplyRequestとしてJSONまたはYAML形式で出力する
+func (s *Service) ExportWithContext(ctx context.Context, req *ExportRequest) ([]byte, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	applyRequest, err := (&UpdateRequest{Zone: req.Zone, ID: req.ID}).ApplyRequest(ctx, s.caller)
+	if err != nil {
+		return nil, err
+	}
+	if !req.IncludeSecrets {
+		redactSecrets(applyRequest)
+	}
+	return serviceutil.MarshalRequest(applyRequest, req.Format)
+}
+
+// redactSecrets パスワードを空にする
+//
+// パスワードを空にしたApplyRequestを再適用する場合はパスワードを設定する必要がある
+func redactSecrets(req *ApplyRequest) {
+	req.Password = ""
+	req.ReplicaUserPassword = ""
+}
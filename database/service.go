@@ -14,7 +14,10 @@
 
 package database
 
-import "github.com/sacloud/iaas-api-go"
+import (
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
 
 // Service provides a high-level API of for Database
 type Service struct {
@@ -25,3 +28,13 @@ type Service struct {
 func New(caller iaas.APICaller) *Service {
 	return &Service{caller: caller}
 }
+
+var (
+	_ service.Creator[*CreateRequest, *iaas.Database]    = (*Service)(nil)
+	_ service.Reader[*ReadRequest, *iaas.Database]       = (*Service)(nil)
+	_ service.Updater[*UpdateRequest, *iaas.Database]    = (*Service)(nil)
+	_ service.Deleter[*DeleteRequest]                    = (*Service)(nil)
+	_ service.Finder[*FindRequest, *iaas.Database]       = (*Service)(nil)
+	_ service.FindIterator[*FindRequest, *iaas.Database] = (*Service)(nil)
+	_ service.Applier[*ApplyRequest, *iaas.Database]     = (*Service)(nil)
+)
@@ -25,12 +25,14 @@ import (
 	"github.com/sacloud/iaas-api-go/ostype"
 	"github.com/sacloud/iaas-api-go/types"
 	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/progress"
 	"github.com/sacloud/packages-go/size"
 )
 
 // Builder ディスクの構築インターフェース
 type Builder interface {
 	Validate(ctx context.Context, zone string) error
+	// Build ディスクを構築する、ディスク作成後に失敗した場合は作成したディスクのIDを含むBuildResultとエラーを返す
 	Build(ctx context.Context, zone string, serverID types.ID) (*BuildResult, error)
 	Update(ctx context.Context, zone string) (*UpdateResult, error)
 	DiskID() types.ID
@@ -93,7 +95,7 @@ func (d *FromUnixBuilder) Validate(ctx context.Context, zone string) error {
 func (d *FromUnixBuilder) Build(ctx context.Context, zone string, serverID types.ID) (*BuildResult, error) {
 	res, err := build(ctx, d.Client, zone, serverID, d.DistantFrom, d.KMSKeyID, d.DedicatedStorageContractID, d)
 	if err != nil {
-		return nil, err
+		return res, err
 	}
 	d.ID = res.DiskID
 
@@ -101,7 +103,7 @@ func (d *FromUnixBuilder) Build(ctx context.Context, zone string, serverID types
 		if d.EditParameter.IsNotesEphemeral {
 			for _, note := range d.generatedNotes {
 				if err := d.Client.Note.Delete(ctx, note.ID); err != nil {
-					return nil, err
+					return res, err
 				}
 			}
 		}
@@ -210,7 +212,7 @@ func (d *FromFixedArchiveBuilder) Validate(ctx context.Context, zone string) err
 func (d *FromFixedArchiveBuilder) Build(ctx context.Context, zone string, serverID types.ID) (*BuildResult, error) {
 	res, err := build(ctx, d.Client, zone, serverID, d.DistantFrom, d.KMSKeyID, d.DedicatedStorageContractID, d)
 	if err != nil {
-		return nil, err
+		return res, err
 	}
 	d.ID = res.DiskID
 	return res, nil
@@ -322,14 +324,14 @@ func (d *FromDiskOrArchiveBuilder) Validate(ctx context.Context, zone string) er
 func (d *FromDiskOrArchiveBuilder) Build(ctx context.Context, zone string, serverID types.ID) (*BuildResult, error) {
 	res, err := build(ctx, d.Client, zone, serverID, d.DistantFrom, d.KMSKeyID, d.DedicatedStorageContractID, d)
 	if err != nil {
-		return nil, err
+		return res, err
 	}
 	d.ID = res.DiskID
 	if d.EditParameter != nil {
 		if d.EditParameter.IsNotesEphemeral {
 			for _, note := range d.generatedNotes {
 				if err := d.Client.Note.Delete(ctx, note.ID); err != nil {
-					return nil, err
+					return res, err
 				}
 			}
 		}
@@ -427,7 +429,7 @@ func (d *BlankBuilder) Validate(ctx context.Context, zone string) error {
 func (d *BlankBuilder) Build(ctx context.Context, zone string, serverID types.ID) (*BuildResult, error) {
 	res, err := build(ctx, d.Client, zone, serverID, d.DistantFrom, d.KMSKeyID, d.DedicatedStorageContractID, d)
 	if err != nil {
-		return nil, err
+		return res, err
 	}
 	d.ID = res.DiskID
 	return res, err
@@ -640,6 +642,8 @@ func build(ctx context.Context, client *APIClient, zone string, serverID types.I
 		return nil, err
 	}
 
+	progress.Emit(ctx, &progress.Event{Type: progress.EventCreated, Resource: "Disk", Zone: zone, ID: disk.ID})
+
 	if builder.NoWaitFlag() {
 		return &BuildResult{DiskID: disk.ID}, nil
 	}
@@ -647,12 +651,9 @@ func build(ctx context.Context, client *APIClient, zone string, serverID types.I
 	waiter := iaas.WaiterForReady(func() (interface{}, error) {
 		return client.Disk.Read(ctx, zone, disk.ID)
 	})
-	lastState, err := waiter.WaitForState(ctx)
+	lastState, err := progress.WaitForState(ctx, waiter, "Disk", zone, disk.ID)
 	if err != nil {
-		if lastState != nil {
-			return &BuildResult{DiskID: lastState.(*iaas.Disk).ID}, err
-		}
-		return nil, err
+		return &BuildResult{DiskID: disk.ID}, err
 	}
 	disk = lastState.(*iaas.Disk)
 
@@ -699,7 +700,7 @@ func update(ctx context.Context, client *APIClient, zone string, builder diskBui
 	waiter := iaas.WaiterForReady(func() (interface{}, error) {
 		return client.Disk.Read(ctx, zone, disk.ID)
 	})
-	lastState, err := waiter.WaitForState(ctx)
+	lastState, err := progress.WaitForState(ctx, waiter, "Disk", zone, disk.ID)
 	if err != nil {
 		return nil, err
 	}
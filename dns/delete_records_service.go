@@ -0,0 +1,47 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dns
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+func (s *Service) DeleteRecords(req *DeleteRecordsRequest) (iaas.DNSRecords, error) {
+	return s.DeleteRecordsWithContext(context.Background(), req)
+}
+
+// DeleteRecordsWithContext Name/Type/RDataが一致するレコードを削除する。TTLは考慮しない
+//
+// 更新後のレコードの一覧を返す
+func (s *Service) DeleteRecordsWithContext(ctx context.Context, req *DeleteRecordsRequest) (iaas.DNSRecords, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.MutateWithContext(ctx, &MutateRequest{
+		ID:         req.ID,
+		MaxRetries: req.MaxRetries,
+		Mutator: func(current *iaas.DNS) error {
+			current.Records = deleteRecords(current.Records, req.Records)
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated.Records, nil
+}
@@ -0,0 +1,239 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dns01
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/dns"
+)
+
+const (
+	// DefaultTTL チャレンジ用TXTレコードのTTLのデフォルト値
+	DefaultTTL = 60
+	// DefaultPropagationTimeout レコードがネームサーバに反映されるまで待つ時間のデフォルト値
+	DefaultPropagationTimeout = 5 * time.Minute
+	// DefaultPollingInterval レコードの反映を確認する間隔のデフォルト値
+	DefaultPollingInterval = 10 * time.Second
+)
+
+// LookupTXTFunc nameserverに対してfqdnのTXTレコードを問い合わせる
+type LookupTXTFunc func(ctx context.Context, nameserver, fqdn string) ([]string, error)
+
+// Provider さくらのクラウドDNSを用いてACMEのDNS-01チャレンジを行う
+//
+// Present/CleanUp/Timeoutを実装しており、legoのchallenge.Provider/challenge.ProviderTimeoutとして利用できる
+type Provider struct {
+	Caller iaas.APICaller
+
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+
+	// LookupTXT レコードの反映確認に利用する、nilの場合はネームサーバへ直接問い合わせる
+	LookupTXT LookupTXTFunc
+}
+
+// NewProvider デフォルト値が設定されたProviderを返す
+func NewProvider(caller iaas.APICaller) *Provider {
+	return &Provider{
+		Caller:             caller,
+		TTL:                DefaultTTL,
+		PropagationTimeout: DefaultPropagationTimeout,
+		PollingInterval:    DefaultPollingInterval,
+	}
+}
+
+// ChallengeRecord DNS-01チャレンジで登録するTXTレコードのFQDNと値を返す
+func ChallengeRecord(domain, keyAuth string) (fqdn string, value string) {
+	domain = strings.TrimPrefix(strings.ToLower(strings.TrimSuffix(domain, ".")), "*.")
+	sum := sha256.Sum256([]byte(keyAuth))
+	return "_acme-challenge." + domain + ".", base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Timeout レコードの反映を待つ時間と確認間隔を返す
+func (p *Provider) Timeout() (timeout, interval time.Duration) {
+	return p.propagationTimeout(), p.pollingInterval()
+}
+
+// Present チャレンジ用のTXTレコードを登録し、ゾーンのネームサーバに反映されるまで待つ
+func (p *Provider) Present(domain, token, keyAuth string) error {
+	return p.PresentWithContext(context.Background(), domain, token, keyAuth)
+}
+
+// PresentWithContext チャレンジ用のTXTレコードを登録し、ゾーンのネームサーバに反映されるまで待つ
+func (p *Provider) PresentWithContext(ctx context.Context, domain, _, keyAuth string) error {
+	fqdn, value := ChallengeRecord(domain, keyAuth)
+
+	zone, err := p.findZone(ctx, fqdn)
+	if err != nil {
+		return err
+	}
+
+	_, err = dns.New(p.Caller).AddRecordsWithContext(ctx, &dns.AddRecordsRequest{
+		ID:      zone.ID,
+		Records: iaas.DNSRecords{p.record(zone, fqdn, value)},
+	})
+	if err != nil {
+		return fmt.Errorf("adding TXT record %q to DNS[%s] failed: %s", fqdn, zone.ID, err)
+	}
+
+	return p.waitForPropagation(ctx, zone, fqdn, value)
+}
+
+// CleanUp Presentで登録したTXTレコードを削除する
+func (p *Provider) CleanUp(domain, token, keyAuth string) error {
+	return p.CleanUpWithContext(context.Background(), domain, token, keyAuth)
+}
+
+// CleanUpWithContext Presentで登録したTXTレコードを削除する
+func (p *Provider) CleanUpWithContext(ctx context.Context, domain, _, keyAuth string) error {
+	fqdn, value := ChallengeRecord(domain, keyAuth)
+
+	zone, err := p.findZone(ctx, fqdn)
+	if err != nil {
+		return err
+	}
+
+	_, err = dns.New(p.Caller).DeleteRecordsWithContext(ctx, &dns.DeleteRecordsRequest{
+		ID:      zone.ID,
+		Records: iaas.DNSRecords{p.record(zone, fqdn, value)},
+	})
+	if err != nil {
+		return fmt.Errorf("deleting TXT record %q from DNS[%s] failed: %s", fqdn, zone.ID, err)
+	}
+	return nil
+}
+
+func (p *Provider) record(zone *iaas.DNS, fqdn, value string) *iaas.DNSRecord {
+	ttl := p.TTL
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &iaas.DNSRecord{
+		Name:  relativeName(fqdn, zone.Name),
+		Type:  types.DNSRecordTypes.TXT,
+		RData: value,
+		TTL:   ttl,
+	}
+}
+
+func (p *Provider) findZone(ctx context.Context, fqdn string) (*iaas.DNS, error) {
+	var zones []*iaas.DNS
+	for zone, err := range dns.New(p.Caller).FindIterWithContext(ctx, &dns.FindRequest{}) {
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, zone)
+	}
+
+	zone := matchZone(zones, fqdn)
+	if zone == nil {
+		return nil, fmt.Errorf("DNS zone for %q is not found", fqdn)
+	}
+	return zone, nil
+}
+
+func (p *Provider) waitForPropagation(ctx context.Context, zone *iaas.DNS, fqdn, value string) error {
+	ctx, cancel := context.WithTimeout(ctx, p.propagationTimeout())
+	defer cancel()
+
+	lookup := p.LookupTXT
+	if lookup == nil {
+		lookup = lookupTXT
+	}
+
+	for {
+		propagated := true
+		for _, ns := range zone.DNSNameServers {
+			values, err := lookup(ctx, ns, fqdn)
+			if err != nil || !slices.Contains(values, value) {
+				propagated = false
+				break
+			}
+		}
+		if propagated {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for TXT record %q to propagate failed: %w", fqdn, ctx.Err())
+		case <-time.After(p.pollingInterval()):
+		}
+	}
+}
+
+func (p *Provider) propagationTimeout() time.Duration {
+	if p.PropagationTimeout <= 0 {
+		return DefaultPropagationTimeout
+	}
+	return p.PropagationTimeout
+}
+
+func (p *Provider) pollingInterval() time.Duration {
+	if p.PollingInterval <= 0 {
+		return DefaultPollingInterval
+	}
+	return p.PollingInterval
+}
+
+// matchZone fqdnを含むゾーンのうち最も長いゾーン名を持つものを返す
+func matchZone(zones []*iaas.DNS, fqdn string) *iaas.DNS {
+	fqdn = strings.ToLower(fqdn)
+	var found *iaas.DNS
+	var foundName string
+	for _, zone := range zones {
+		name := strings.ToLower(strings.TrimSuffix(zone.Name, ".")) + "."
+		if fqdn != name && !strings.HasSuffix(fqdn, "."+name) {
+			continue
+		}
+		if len(name) > len(foundName) {
+			found, foundName = zone, name
+		}
+	}
+	return found
+}
+
+// relativeName fqdnをzoneからの相対名に変換する
+func relativeName(fqdn, zone string) string {
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	name := strings.TrimSuffix(strings.TrimSuffix(strings.ToLower(fqdn), "."), zone)
+	name = strings.TrimSuffix(name, ".")
+	if name == "" {
+		return "@"
+	}
+	return name
+}
+
+func lookupTXT(ctx context.Context, nameserver, fqdn string) ([]string, error) {
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			d := &net.Dialer{}
+			return d.DialContext(ctx, network, net.JoinHostPort(nameserver, "53"))
+		},
+	}
+	return resolver.LookupTXT(ctx, fqdn)
+}
@@ -0,0 +1,137 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dns01
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/testutil"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/dns"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChallengeRecord(t *testing.T) {
+	for _, domain := range []string{"www.example.com", "WWW.example.com.", "*.www.example.com"} {
+		fqdn, value := ChallengeRecord(domain, "token.thumbprint")
+		require.Equal(t, "_acme-challenge.www.example.com.", fqdn)
+		require.Equal(t, "61rBZ_4knHblO0MNoxFsXZ_eTFUHum0B6IVRbhvUn5I", value)
+	}
+}
+
+func TestMatchZone(t *testing.T) {
+	zones := []*iaas.DNS{
+		{ID: 1, Name: "example.com"},
+		{ID: 2, Name: "sub.example.com"},
+		{ID: 3, Name: "ample.com"},
+	}
+
+	cases := []struct {
+		fqdn     string
+		expected types.ID
+	}{
+		{fqdn: "_acme-challenge.example.com.", expected: 1},
+		{fqdn: "_acme-challenge.www.example.com.", expected: 1},
+		{fqdn: "_acme-challenge.sub.example.com.", expected: 2},
+		{fqdn: "_acme-challenge.www.sub.example.com.", expected: 2},
+		{fqdn: "_acme-challenge.ample.com.", expected: 3},
+		{fqdn: "_acme-challenge.example.net.", expected: 0},
+	}
+	for _, tc := range cases {
+		zone := matchZone(zones, tc.fqdn)
+		if tc.expected.IsEmpty() {
+			require.Nil(t, zone, tc.fqdn)
+			continue
+		}
+		require.NotNil(t, zone, tc.fqdn)
+		require.Equal(t, tc.expected, zone.ID, tc.fqdn)
+	}
+}
+
+func TestRelativeName(t *testing.T) {
+	require.Equal(t, "_acme-challenge", relativeName("_acme-challenge.example.com.", "example.com"))
+	require.Equal(t, "_acme-challenge.www", relativeName("_acme-challenge.www.example.com.", "example.com."))
+	require.Equal(t, "@", relativeName("example.com.", "example.com"))
+}
+
+func TestProvider(t *testing.T) {
+	prefix := testutil.RandomPrefix()
+	name := prefix + "dns01-provider.com"
+
+	var zone *iaas.DNS
+	var provider *Provider
+
+	testutil.RunResource(t, &testutil.ResourceTestCase{
+		SetupAPICallerFunc: testutil.SingletonAPICaller,
+		Setup: func(ctx context.Context, caller iaas.APICaller) error {
+			created, err := dns.New(caller).CreateWithContext(ctx, &dns.CreateRequest{Name: name})
+			if err != nil {
+				return err
+			}
+			zone = created
+
+			provider = NewProvider(caller)
+			provider.PollingInterval = time.Millisecond
+			provider.LookupTXT = func(ctx context.Context, nameserver, fqdn string) ([]string, error) {
+				current, err := dns.New(caller).ReadWithContext(ctx, &dns.ReadRequest{ID: zone.ID})
+				if err != nil {
+					return nil, err
+				}
+				var values []string
+				for _, r := range current.Records {
+					if r.Type == types.DNSRecordTypes.TXT && r.Name+"."+name+"." == fqdn {
+						values = append(values, r.RData)
+					}
+				}
+				return values, nil
+			}
+			return nil
+		},
+		Tests: []testutil.ResourceTestFunc{
+			func(ctx context.Context, caller iaas.APICaller) error {
+				if err := provider.Present("www."+name, "", "token.thumbprint"); err != nil {
+					return err
+				}
+				current, err := dns.New(caller).ReadWithContext(ctx, &dns.ReadRequest{ID: zone.ID})
+				if err != nil {
+					return err
+				}
+				return testutil.DoAsserts(
+					testutil.AssertLenFunc(t, current.Records, 1, "Records"),
+					testutil.AssertEqualFunc(t, "_acme-challenge.www", current.Records[0].Name, "Records.Name"),
+					testutil.AssertEqualFunc(t, DefaultTTL, current.Records[0].TTL, "Records.TTL"),
+				)
+			},
+			func(ctx context.Context, caller iaas.APICaller) error {
+				if err := provider.CleanUp("www."+name, "", "token.thumbprint"); err != nil {
+					return err
+				}
+				current, err := dns.New(caller).ReadWithContext(ctx, &dns.ReadRequest{ID: zone.ID})
+				if err != nil {
+					return err
+				}
+				return testutil.AssertLenFunc(t, current.Records, 0, "Records")
+			},
+			func(ctx context.Context, caller iaas.APICaller) error {
+				return dns.New(caller).DeleteWithContext(ctx, &dns.DeleteRequest{ID: zone.ID})
+			},
+		},
+		Cleanup:  testutil.ComposeCleanupResourceFunc(prefix, testutil.CleanupTargets.DNS),
+		Parallel: true,
+	})
+}
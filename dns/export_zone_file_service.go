@@ -0,0 +1,44 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dns
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+func (s *Service) ExportZoneFile(req *ExportZoneFileRequest) ([]byte, error) {
+	return s.ExportZoneFileWithContext(context.Background(), req)
+}
+
+// ExportZoneFileWithContext ゾーンをRFC 1035形式のゾーンファイルとして出力する
+func (s *Service) ExportZoneFileWithContext(ctx context.Context, req *ExportZoneFileRequest) ([]byte, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	zone, err := iaas.NewDNSOp(s.caller).Read(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := WriteZoneFile(buf, zone); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
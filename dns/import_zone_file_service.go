@@ -0,0 +1,58 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dns
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+func (s *Service) ImportZoneFile(req *ImportZoneFileRequest) (*iaas.DNS, error) {
+	return s.ImportZoneFileWithContext(context.Background(), req)
+}
+
+// ImportZoneFileWithContext ゾーンファイルを読み込み、ゾーンのレコードを置き換える
+func (s *Service) ImportZoneFileWithContext(ctx context.Context, req *ImportZoneFileRequest) (*iaas.DNS, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	client := iaas.NewDNSOp(s.caller)
+	current, err := client.Read(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("reading DNS[%s] failed: %s", req.ID, err)
+	}
+
+	records, err := ParseZoneFile(current.Name, bytes.NewReader(req.ZoneFile))
+	if err != nil {
+		return nil, fmt.Errorf("parsing zone file failed: %s", err)
+	}
+
+	params, err := (&UpdateRequest{ID: req.ID, SettingsHash: current.SettingsHash}).ToRequestParameter(current)
+	if err != nil {
+		return nil, fmt.Errorf("processing request parameter failed: %s", err)
+	}
+	// UpdateRequest.Recordsはomitemptyのため経由せずに指定する、
+	// ゾーンファイルにSOA/NSレコードしか含まれない場合も既存のレコードを空で置き換える
+	if records == nil {
+		records = iaas.DNSRecords{}
+	}
+	params.Records = records
+
+	return client.Update(ctx, req.ID, params)
+}
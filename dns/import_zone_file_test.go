@@ -0,0 +1,58 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/testutil"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_ImportZoneFile(t *testing.T) {
+	if testutil.IsAccTest() {
+		t.Skip("This test runs only without TESTACC=1")
+	}
+
+	ctx := context.Background()
+	svc := New(testutil.SingletonAPICaller())
+	name := testutil.RandomPrefix() + "dns-import.com"
+
+	dns, err := svc.CreateWithContext(ctx, &CreateRequest{
+		Name: name,
+		Records: iaas.DNSRecords{
+			&iaas.DNSRecord{Name: "www", Type: types.DNSRecordTypes.A, RData: "192.0.2.1", TTL: 300},
+		},
+	})
+	require.NoError(t, err)
+	defer svc.DeleteWithContext(ctx, &DeleteRequest{ID: dns.ID}) //nolint:errcheck
+
+	// SOA/NSレコードのみの場合は既存のレコードが削除される
+	zoneFile := "@ IN SOA ns1.example.net. hostmaster.example.com. 2024010101 3600 900 604800 300\n" +
+		"@ NS ns1.example.net.\n"
+	imported, err := svc.ImportZoneFileWithContext(ctx, &ImportZoneFileRequest{
+		ID:       dns.ID,
+		ZoneFile: []byte(zoneFile),
+	})
+	require.NoError(t, err)
+	require.Empty(t, imported.Records)
+
+	read, err := svc.ReadWithContext(ctx, &ReadRequest{ID: dns.ID})
+	require.NoError(t, err)
+	require.Empty(t, read.Records)
+}
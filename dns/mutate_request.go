@@ -0,0 +1,38 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dns
+
+import (
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/packages-go/validate"
+)
+
+// MutateRequest 現在のDNSを読み込み、Mutatorで変更を加えてから更新するためのリクエスト
+//
+// 更新時には読み込み時点のSettingsHashを送信するため、他者による更新とコンフリクトした場合は
+// 読み込みからやり直す
+type MutateRequest struct {
+	ID types.ID `validate:"required"`
+
+	// Mutator 現在のリソースを受け取り変更を加える
+	Mutator func(current *iaas.DNS) error `validate:"required"`
+	// MaxRetries コンフリクト時の最大リトライ回数、0の場合はserviceutil.DefaultConflictRetryCountを利用する
+	MaxRetries int `validate:"min=0"`
+}
+
+func (req *MutateRequest) Validate() error {
+	return validate.New().Struct(req)
+}
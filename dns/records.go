@@ -0,0 +1,69 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dns
+
+import (
+	"strings"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+// recordEquals Name/Type/RDataでレコードが同一か判定する
+//
+// Nameは大文字小文字を区別しない
+func recordEquals(r1, r2 *iaas.DNSRecord) bool {
+	return strings.EqualFold(r1.Name, r2.Name) && r1.Type == r2.Type && r1.RData == r2.RData
+}
+
+func findRecord(records iaas.DNSRecords, record *iaas.DNSRecord) int {
+	for i, r := range records {
+		if recordEquals(r, record) {
+			return i
+		}
+	}
+	return -1
+}
+
+func addRecords(current, records iaas.DNSRecords) iaas.DNSRecords {
+	results := append(iaas.DNSRecords{}, current...)
+	for _, r := range records {
+		if findRecord(results, r) < 0 {
+			results = append(results, r)
+		}
+	}
+	return results
+}
+
+func deleteRecords(current, records iaas.DNSRecords) iaas.DNSRecords {
+	results := iaas.DNSRecords{}
+	for _, r := range current {
+		if findRecord(records, r) < 0 {
+			results = append(results, r)
+		}
+	}
+	return results
+}
+
+func upsertRecords(current, records iaas.DNSRecords) iaas.DNSRecords {
+	results := append(iaas.DNSRecords{}, current...)
+	for _, r := range records {
+		if i := findRecord(results, r); i >= 0 {
+			results[i] = r
+			continue
+		}
+		results = append(results, r)
+	}
+	return results
+}
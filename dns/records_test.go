@@ -0,0 +1,61 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dns
+
+import (
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecords(t *testing.T) {
+	current := iaas.DNSRecords{
+		&iaas.DNSRecord{Name: "www", Type: types.DNSRecordTypes.A, RData: "192.0.2.1", TTL: 300},
+		&iaas.DNSRecord{Name: "www", Type: types.DNSRecordTypes.A, RData: "192.0.2.2", TTL: 300},
+		&iaas.DNSRecord{Name: "@", Type: types.DNSRecordTypes.TXT, RData: "v=spf1 -all", TTL: 3600},
+	}
+
+	t.Run("add", func(t *testing.T) {
+		results := addRecords(current, iaas.DNSRecords{
+			&iaas.DNSRecord{Name: "WWW", Type: types.DNSRecordTypes.A, RData: "192.0.2.1", TTL: 60},
+			&iaas.DNSRecord{Name: "_acme-challenge", Type: types.DNSRecordTypes.TXT, RData: "token", TTL: 60},
+		})
+		require.Len(t, results, 4)
+		require.Equal(t, 300, results[0].TTL)
+		require.Equal(t, "_acme-challenge", results[3].Name)
+		require.Len(t, current, 3)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		results := deleteRecords(current, iaas.DNSRecords{
+			&iaas.DNSRecord{Name: "www", Type: types.DNSRecordTypes.A, RData: "192.0.2.2"},
+			&iaas.DNSRecord{Name: "www", Type: types.DNSRecordTypes.AAAA, RData: "192.0.2.1"},
+		})
+		require.Equal(t, iaas.DNSRecords{current[0], current[2]}, results)
+	})
+
+	t.Run("upsert", func(t *testing.T) {
+		results := upsertRecords(current, iaas.DNSRecords{
+			&iaas.DNSRecord{Name: "www", Type: types.DNSRecordTypes.A, RData: "192.0.2.2", TTL: 60},
+			&iaas.DNSRecord{Name: "mail", Type: types.DNSRecordTypes.A, RData: "192.0.2.3", TTL: 60},
+		})
+		require.Len(t, results, 4)
+		require.Equal(t, 60, results[1].TTL)
+		require.Equal(t, "mail", results[3].Name)
+		require.Equal(t, 300, current[1].TTL)
+	})
+}
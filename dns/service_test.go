@@ -67,6 +67,28 @@ func TestService_CRUD(t *testing.T) {
 					testutil.AssertEqualFunc(t, types.Tags{"tag1-upd", "tag2-upd"}, updated.Tags, "Tags"),
 				)
 			},
+			// mutate zone
+			func(ctx context.Context, caller iaas.APICaller) error {
+				updated, err := svc.Mutate(&MutateRequest{
+					ID: dns.ID,
+					Mutator: func(current *iaas.DNS) error {
+						current.Records = append(current.Records, &iaas.DNSRecord{
+							Name:  "www",
+							Type:  types.DNSRecordTypes.A,
+							RData: "192.0.2.1",
+							TTL:   300,
+						})
+						return nil
+					},
+				})
+				if err != nil {
+					return err
+				}
+				return testutil.DoAsserts(
+					testutil.AssertEqualFunc(t, "description-upd", updated.Description, "Description"),
+					testutil.AssertLenFunc(t, updated.Records, 1, "Records"),
+				)
+			},
 			// delete zone
 			func(ctx context.Context, caller iaas.APICaller) error {
 				return svc.Delete(&DeleteRequest{ID: dns.ID})
@@ -0,0 +1,497 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dns
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// DefaultZoneFileTTL ゾーンファイルにTTLの指定がない場合に利用されるTTL
+const DefaultZoneFileTTL = 3600
+
+// maxTXTStringLength ゾーンファイル出力時にTXTレコードを分割する文字数
+const maxTXTStringLength = 255
+
+// zoneFileRecordTypes ゾーンファイルから読み込み可能なレコードタイプ
+var zoneFileRecordTypes = map[string]types.EDNSRecordType{
+	"A":     types.DNSRecordTypes.A,
+	"AAAA":  types.DNSRecordTypes.AAAA,
+	"ALIAS": types.DNSRecordTypes.ALIAS,
+	"CNAME": types.DNSRecordTypes.CNAME,
+	"NS":    types.DNSRecordTypes.NS,
+	"MX":    types.DNSRecordTypes.MX,
+	"TXT":   types.DNSRecordTypes.TXT,
+	"SRV":   types.DNSRecordTypes.SRV,
+	"CAA":   types.DNSRecordTypes.CAA,
+	"PTR":   types.DNSRecordTypes.PTR,
+	"HTTPS": types.DNSRecordTypes.HTTPS,
+	"SVCB":  types.DNSRecordTypes.SVCB,
+}
+
+// ParseZoneFile RFC 1035形式のゾーンファイルを読み込みレコードの一覧を返す
+//
+// zoneはゾーン名(例: example.com)で、$ORIGINの初期値として利用される。
+// レコード名はゾーンからの相対名(ゾーン自身は@)に変換される。
+// SOAレコードとゾーン自身のNSレコードはさくらのクラウド側で管理されるため読み飛ばす。
+func ParseZoneFile(zone string, r io.Reader) (iaas.DNSRecords, error) {
+	p := &zoneFileParser{
+		zone:    fqdn(zone),
+		origin:  fqdn(zone),
+		ttl:     -1,
+		lastTTL: -1,
+	}
+	if err := p.parse(r); err != nil {
+		return nil, err
+	}
+	return p.records, nil
+}
+
+// WriteZoneFile DNSゾーンをゾーンファイルとしてwに出力する
+//
+// 出力はレコード名、タイプ、RDataの順にソートされる。
+// ゾーン自身のNSレコードとしてさくらのクラウドのネームサーバを出力する。
+func WriteZoneFile(w io.Writer, zone *iaas.DNS) error {
+	origin := fqdn(zone.Name)
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "$ORIGIN %s\n", origin)
+	fmt.Fprintf(bw, "$TTL %d\n", DefaultZoneFileTTL)
+	for _, ns := range zone.DNSNameServers {
+		fmt.Fprintf(bw, "@\t%d\tIN\tNS\t%s\n", DefaultZoneFileTTL, fqdn(ns))
+	}
+
+	records := append(iaas.DNSRecords{}, zone.Records...)
+	sort.SliceStable(records, func(i, j int) bool {
+		ni, nj := recordName(records[i].Name), recordName(records[j].Name)
+		if ni != nj {
+			if ni == "@" || nj == "@" {
+				return ni == "@"
+			}
+			return ni < nj
+		}
+		if records[i].Type != records[j].Type {
+			return records[i].Type < records[j].Type
+		}
+		return records[i].RData < records[j].RData
+	})
+
+	for _, record := range records {
+		rdata := record.RData
+		if record.Type == types.DNSRecordTypes.TXT {
+			rdata = quoteTXT(rdata)
+		}
+		ttl := record.TTL
+		if ttl == 0 {
+			ttl = DefaultZoneFileTTL
+		}
+		fmt.Fprintf(bw, "%s\t%d\tIN\t%s\t%s\n", recordName(record.Name), ttl, record.Type, rdata)
+	}
+	return bw.Flush()
+}
+
+func recordName(name string) string {
+	if name == "" {
+		return "@"
+	}
+	return name
+}
+
+func fqdn(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+func quoteTXT(value string) string {
+	var chunks []string
+	for {
+		chunk := value
+		if len(chunk) > maxTXTStringLength {
+			chunk = chunk[:maxTXTStringLength]
+		}
+		chunks = append(chunks, quoteString(chunk))
+
+		if len(value) <= maxTXTStringLength {
+			break
+		}
+		value = value[maxTXTStringLength:]
+	}
+	return strings.Join(chunks, " ")
+}
+
+func quoteString(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + value + `"`
+}
+
+type zoneFileToken struct {
+	value  string
+	quoted bool
+}
+
+type zoneFileParser struct {
+	zone    string
+	origin  string
+	ttl     int // $TTLの値、未指定の場合は-1
+	lastTTL int // 直前のレコードのTTL、未指定の場合は-1
+	owner   string
+	records iaas.DNSRecords
+}
+
+func (p *zoneFileParser) parse(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var tokens []zoneFileToken
+	var startLine, lineNo, depth int
+	var continued bool
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+		if depth == 0 {
+			startLine = lineNo
+			continued = len(line) > 0 && (line[0] == ' ' || line[0] == '\t')
+		}
+
+		lineTokens, d, err := tokenizeZoneFileLine(line, depth)
+		if err != nil {
+			return fmt.Errorf("line %d: %s", lineNo, err)
+		}
+		depth = d
+		tokens = append(tokens, lineTokens...)
+		if depth > 0 {
+			continue
+		}
+
+		if len(tokens) > 0 {
+			if err := p.parseEntry(tokens, continued); err != nil {
+				return fmt.Errorf("line %d: %s", startLine, err)
+			}
+		}
+		tokens = nil
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if depth > 0 {
+		return fmt.Errorf("line %d: unclosed parenthesis", startLine)
+	}
+	return nil
+}
+
+// tokenizeZoneFileLine 1行分をトークンに分割する
+//
+// depthは行頭時点での括弧のネストの深さ、戻り値として行末時点での深さを返す
+func tokenizeZoneFileLine(line string, depth int) ([]zoneFileToken, int, error) {
+	var tokens []zoneFileToken
+	var current strings.Builder
+	inToken, inQuote, inTokenQuote := false, false, false
+
+	flush := func(quoted bool) {
+		if inToken || quoted {
+			tokens = append(tokens, zoneFileToken{value: current.String(), quoted: quoted})
+		}
+		current.Reset()
+		inToken = false
+	}
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line):
+			if inTokenQuote {
+				current.WriteByte(c)
+			}
+			i++
+			current.WriteByte(line[i])
+			inToken = true
+		case inQuote:
+			if c == '"' {
+				inQuote = false
+				flush(true)
+			} else {
+				current.WriteByte(c)
+			}
+		case inTokenQuote:
+			// key="value"のようにトークンの途中から始まる引用符はそのまま保持する
+			current.WriteByte(c)
+			if c == '"' {
+				inTokenQuote = false
+			}
+		case c == '"':
+			if inToken {
+				current.WriteByte(c)
+				inTokenQuote = true
+				continue
+			}
+			inQuote = true
+		case c == ';':
+			flush(false)
+			return tokens, depth, nil
+		case c == '(':
+			flush(false)
+			depth++
+		case c == ')':
+			flush(false)
+			if depth == 0 {
+				return nil, 0, fmt.Errorf("unexpected ')'")
+			}
+			depth--
+		case c == ' ' || c == '\t':
+			flush(false)
+		default:
+			current.WriteByte(c)
+			inToken = true
+		}
+	}
+	if inQuote || inTokenQuote {
+		return nil, 0, fmt.Errorf("unterminated quoted string")
+	}
+	flush(false)
+	return tokens, depth, nil
+}
+
+func (p *zoneFileParser) parseEntry(tokens []zoneFileToken, continued bool) error {
+	if !continued && strings.HasPrefix(tokens[0].value, "$") {
+		return p.parseDirective(tokens)
+	}
+
+	owner := p.owner
+	if !continued {
+		owner = p.absoluteName(tokens[0].value)
+		tokens = tokens[1:]
+	}
+	if owner == "" {
+		return fmt.Errorf("owner name is not specified")
+	}
+	p.owner = owner
+
+	ttl := -1
+	var typ string
+	for len(tokens) > 0 && typ == "" {
+		v := tokens[0].value
+		tokens = tokens[1:]
+		switch {
+		case strings.EqualFold(v, "IN"):
+		case strings.EqualFold(v, "CH") || strings.EqualFold(v, "HS") || strings.EqualFold(v, "CS"):
+			return fmt.Errorf("unsupported class %q", v)
+		default:
+			if t, err := parseTTL(v); err == nil && ttl < 0 {
+				ttl = t
+				continue
+			}
+			typ = strings.ToUpper(v)
+		}
+	}
+	if typ == "" {
+		return fmt.Errorf("record type is not specified")
+	}
+	if typ == "SOA" {
+		return nil
+	}
+
+	recordType, ok := zoneFileRecordTypes[typ]
+	if !ok {
+		return fmt.Errorf("unsupported record type %q", typ)
+	}
+	if len(tokens) == 0 {
+		return fmt.Errorf("%s record requires RDATA", typ)
+	}
+
+	if ttl < 0 {
+		ttl = p.ttl
+	}
+	if ttl < 0 {
+		ttl = p.lastTTL
+	}
+	if ttl < 0 {
+		ttl = DefaultZoneFileTTL
+	}
+	p.lastTTL = ttl
+
+	name, err := p.relativeName(owner)
+	if err != nil {
+		return err
+	}
+	if name == "@" && recordType == types.DNSRecordTypes.NS {
+		return nil
+	}
+
+	rdata, err := p.rdata(recordType, tokens)
+	if err != nil {
+		return err
+	}
+	p.records = append(p.records, &iaas.DNSRecord{
+		Name:  name,
+		Type:  recordType,
+		RData: rdata,
+		TTL:   ttl,
+	})
+	return nil
+}
+
+func (p *zoneFileParser) parseDirective(tokens []zoneFileToken) error {
+	directive := strings.ToUpper(tokens[0].value)
+	switch directive {
+	case "$ORIGIN":
+		if len(tokens) != 2 {
+			return fmt.Errorf("$ORIGIN requires a domain name")
+		}
+		p.origin = p.absoluteName(tokens[1].value)
+	case "$TTL":
+		if len(tokens) != 2 {
+			return fmt.Errorf("$TTL requires a TTL value")
+		}
+		ttl, err := parseTTL(tokens[1].value)
+		if err != nil {
+			return err
+		}
+		p.ttl = ttl
+	default:
+		return fmt.Errorf("unsupported directive %q", directive)
+	}
+	return nil
+}
+
+// absoluteName 現在の$ORIGINを基準に名前をFQDNへ変換する
+func (p *zoneFileParser) absoluteName(name string) string {
+	switch {
+	case name == "@":
+		return p.origin
+	case strings.HasSuffix(name, "."):
+		return strings.ToLower(name)
+	default:
+		return strings.ToLower(name) + "." + p.origin
+	}
+}
+
+// relativeName FQDNをゾーンからの相対名へ変換する
+func (p *zoneFileParser) relativeName(name string) (string, error) {
+	if name == p.zone {
+		return "@", nil
+	}
+	if !strings.HasSuffix(name, "."+p.zone) {
+		return "", fmt.Errorf("name %q is out of zone %q", name, p.zone)
+	}
+	return strings.TrimSuffix(name, "."+p.zone), nil
+}
+
+// targetName RDATA内のドメイン名をFQDNへ変換する
+func (p *zoneFileParser) targetName(name string) string {
+	if name == "." {
+		return name
+	}
+	return p.absoluteName(name)
+}
+
+func (p *zoneFileParser) rdata(recordType types.EDNSRecordType, tokens []zoneFileToken) (string, error) {
+	values := make([]string, len(tokens))
+	for i, t := range tokens {
+		values[i] = t.value
+	}
+
+	expect := func(n int) error {
+		if len(values) != n {
+			return fmt.Errorf("%s record requires %d RDATA fields, got %d", recordType, n, len(values))
+		}
+		return nil
+	}
+
+	switch recordType {
+	case types.DNSRecordTypes.A, types.DNSRecordTypes.AAAA:
+		if err := expect(1); err != nil {
+			return "", err
+		}
+		return values[0], nil
+	case types.DNSRecordTypes.CNAME, types.DNSRecordTypes.NS, types.DNSRecordTypes.ALIAS, types.DNSRecordTypes.PTR:
+		if err := expect(1); err != nil {
+			return "", err
+		}
+		return p.targetName(values[0]), nil
+	case types.DNSRecordTypes.MX:
+		if err := expect(2); err != nil {
+			return "", err
+		}
+		return values[0] + " " + p.targetName(values[1]), nil
+	case types.DNSRecordTypes.SRV:
+		if err := expect(4); err != nil {
+			return "", err
+		}
+		return strings.Join(append(values[:3], p.targetName(values[3])), " "), nil
+	case types.DNSRecordTypes.TXT:
+		return strings.Join(values, ""), nil
+	case types.DNSRecordTypes.CAA:
+		if err := expect(3); err != nil {
+			return "", err
+		}
+		return values[0] + " " + values[1] + " " + quoteString(values[2]), nil
+	case types.DNSRecordTypes.HTTPS, types.DNSRecordTypes.SVCB:
+		if len(values) < 2 {
+			return "", fmt.Errorf("%s record requires at least 2 RDATA fields, got %d", recordType, len(values))
+		}
+		values[1] = p.targetName(values[1])
+		for i, t := range tokens[2:] {
+			if t.quoted {
+				values[i+2] = quoteString(t.value)
+			}
+		}
+		return strings.Join(values, " "), nil
+	}
+	return "", fmt.Errorf("unsupported record type %q", recordType)
+}
+
+// parseTTL TTLを秒数に変換する、BINDの単位(s/m/h/d/w)をサポートする
+func parseTTL(v string) (int, error) {
+	if v == "" {
+		return 0, fmt.Errorf("invalid TTL: empty")
+	}
+	if ttl, err := strconv.Atoi(v); err == nil {
+		if ttl < 0 {
+			return 0, fmt.Errorf("invalid TTL: %q", v)
+		}
+		return ttl, nil
+	}
+
+	units := map[byte]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	total, num, hasNum := 0, 0, false
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		switch {
+		case c >= '0' && c <= '9':
+			num = num*10 + int(c-'0')
+			hasNum = true
+		case hasNum && units[c|0x20] > 0:
+			total += num * units[c|0x20]
+			num, hasNum = 0, false
+		default:
+			return 0, fmt.Errorf("invalid TTL: %q", v)
+		}
+	}
+	if hasNum {
+		return 0, fmt.Errorf("invalid TTL: %q", v)
+	}
+	return total, nil
+}
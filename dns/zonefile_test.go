@@ -0,0 +1,133 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dns
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testZoneFile = `
+$ORIGIN example.com.
+$TTL 1h
+@       IN  SOA ns1.example.net. hostmaster.example.com. (
+                2024010101 ; serial
+                3600 900 604800 300 )
+@           NS    ns1.example.net.
+@       300 IN    A     192.0.2.1
+            IN    AAAA  2001:db8::1
+www     IN  300   CNAME @
+mail.example.com. MX 10 mx1
+@       TXT   "v=spf1 include:_spf.example.net -all"
+dkim._domainkey TXT ( "v=DKIM1; k=rsa; "
+                      "p=MIGfMA0G" )
+_sip._tcp   SRV   10 60 5060 sip.example.net.
+@           CAA   0 issue "letsencrypt.org"
+@           HTTPS 1 . alpn="h2,h3"
+$ORIGIN sub.example.com.
+host    1d  A     192.0.2.10
+alias       ALIAS host
+sub.example.com. NS ns.example.net.
+`
+
+func TestParseZoneFile(t *testing.T) {
+	records, err := ParseZoneFile("example.com", strings.NewReader(testZoneFile))
+	require.NoError(t, err)
+
+	expected := iaas.DNSRecords{
+		&iaas.DNSRecord{Name: "@", Type: types.DNSRecordTypes.A, RData: "192.0.2.1", TTL: 300},
+		&iaas.DNSRecord{Name: "@", Type: types.DNSRecordTypes.AAAA, RData: "2001:db8::1", TTL: 3600},
+		&iaas.DNSRecord{Name: "www", Type: types.DNSRecordTypes.CNAME, RData: "example.com.", TTL: 300},
+		&iaas.DNSRecord{Name: "mail", Type: types.DNSRecordTypes.MX, RData: "10 mx1.example.com.", TTL: 3600},
+		&iaas.DNSRecord{Name: "@", Type: types.DNSRecordTypes.TXT, RData: "v=spf1 include:_spf.example.net -all", TTL: 3600},
+		&iaas.DNSRecord{Name: "dkim._domainkey", Type: types.DNSRecordTypes.TXT, RData: "v=DKIM1; k=rsa; p=MIGfMA0G", TTL: 3600},
+		&iaas.DNSRecord{Name: "_sip._tcp", Type: types.DNSRecordTypes.SRV, RData: "10 60 5060 sip.example.net.", TTL: 3600},
+		&iaas.DNSRecord{Name: "@", Type: types.DNSRecordTypes.CAA, RData: `0 issue "letsencrypt.org"`, TTL: 3600},
+		&iaas.DNSRecord{Name: "@", Type: types.DNSRecordTypes.HTTPS, RData: `1 . alpn="h2,h3"`, TTL: 3600},
+		&iaas.DNSRecord{Name: "host.sub", Type: types.DNSRecordTypes.A, RData: "192.0.2.10", TTL: 86400},
+		&iaas.DNSRecord{Name: "alias.sub", Type: types.DNSRecordTypes.ALIAS, RData: "host.sub.example.com.", TTL: 3600},
+		&iaas.DNSRecord{Name: "sub", Type: types.DNSRecordTypes.NS, RData: "ns.example.net.", TTL: 3600},
+	}
+	require.Equal(t, expected, records)
+
+	// $TTLがない場合は直前のレコードのTTLを引き継ぐ
+	records, err = ParseZoneFile("example.com.", strings.NewReader("a A 192.0.2.1\nb 120 A 192.0.2.2\nc A 192.0.2.3"))
+	require.NoError(t, err)
+	require.Equal(t, DefaultZoneFileTTL, records[0].TTL)
+	require.Equal(t, 120, records[1].TTL)
+	require.Equal(t, 120, records[2].TTL)
+}
+
+func TestParseZoneFile_Errors(t *testing.T) {
+	cases := []struct {
+		in  string
+		err string
+	}{
+		{in: "@ IN DNAME example.net.", err: `line 1: unsupported record type "DNAME"`},
+		{in: "\n@ CH TXT foo", err: `line 2: unsupported class "CH"`},
+		{in: "$INCLUDE other.zone", err: `line 1: unsupported directive "$INCLUDE"`},
+		{in: "www.example.net. A 192.0.2.1", err: `line 1: name "www.example.net." is out of zone "example.com."`},
+		{in: "@ MX 10", err: "line 1: MX record requires 2 RDATA fields, got 1"},
+		{in: "@ TXT ( \"foo\"\n", err: "line 1: unclosed parenthesis"},
+		{in: "@ TXT \"foo", err: "line 1: unterminated quoted string"},
+		{in: "$TTL 1x", err: `line 1: invalid TTL: "1x"`},
+		{in: "  A 192.0.2.1", err: "line 1: owner name is not specified"},
+	}
+	for _, tc := range cases {
+		_, err := ParseZoneFile("example.com", strings.NewReader(tc.in))
+		require.EqualError(t, err, tc.err, tc.in)
+	}
+}
+
+func TestWriteZoneFile(t *testing.T) {
+	zone := &iaas.DNS{
+		Name:           "example.com",
+		DNSNameServers: []string{"ns1.gslb1.sakura.ne.jp", "ns2.gslb1.sakura.ne.jp"},
+		Records: iaas.DNSRecords{
+			&iaas.DNSRecord{Name: "www", Type: types.DNSRecordTypes.A, RData: "192.0.2.2", TTL: 300},
+			&iaas.DNSRecord{Name: "@", Type: types.DNSRecordTypes.TXT, RData: `say "hello"`, TTL: 300},
+			&iaas.DNSRecord{Name: "@", Type: types.DNSRecordTypes.A, RData: "192.0.2.1", TTL: 600},
+			&iaas.DNSRecord{Name: "_dmarc", Type: types.DNSRecordTypes.TXT, RData: strings.Repeat("a", 300), TTL: 300},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteZoneFile(buf, zone))
+
+	expected := strings.Join([]string{
+		"$ORIGIN example.com.",
+		"$TTL 3600",
+		"@\t3600\tIN\tNS\tns1.gslb1.sakura.ne.jp.",
+		"@\t3600\tIN\tNS\tns2.gslb1.sakura.ne.jp.",
+		"@\t600\tIN\tA\t192.0.2.1",
+		"@\t300\tIN\tTXT\t\"say \\\"hello\\\"\"",
+		"_dmarc\t300\tIN\tTXT\t\"" + strings.Repeat("a", 255) + "\" \"" + strings.Repeat("a", 45) + "\"",
+		"www\t300\tIN\tA\t192.0.2.2",
+		"",
+	}, "\n")
+	require.Equal(t, expected, buf.String())
+
+	// 出力したゾーンファイルを再度読み込めること
+	records, err := ParseZoneFile(zone.Name, buf)
+	require.NoError(t, err)
+	require.Len(t, records, len(zone.Records))
+	require.Equal(t, `say "hello"`, records[1].RData)
+	require.Equal(t, strings.Repeat("a", 300), records[2].RData)
+}
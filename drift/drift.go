@@ -0,0 +1,223 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// Result ドリフト検出結果
+type Result struct {
+	Zone  string
+	ID    types.ID
+	Diffs []*Diff
+}
+
+// HasDrift 差分が存在する場合true
+func (r *Result) HasDrift() bool {
+	return r != nil && len(r.Diffs) > 0
+}
+
+// Diff フィールド単位の差分
+type Diff struct {
+	Path    string      // フィールドのパス(例: Disks[0].SizeGB)
+	Current interface{} // 現在の値
+	Desired interface{} // 期待する値
+}
+
+// String 差分を文字列で返す
+func (d *Diff) String() string {
+	return fmt.Sprintf("%s: %v => %v", d.Path, d.Current, d.Desired)
+}
+
+// Detect loadで現在のリソースから組み立てたリクエストとdesiredを比較し、ドリフト検出結果を返す
+//
+// 各サービスのDriftはこの関数を通じて現在のリソースとDesiredを比較する。
+// 対象リソースはzone/idで指定するため、リクエストのZone/IDフィールドはignoreFieldsで除外すること
+func Detect[T any](ctx context.Context, zone string, id types.ID, desired T, load func(ctx context.Context) (T, error), ignoreFields ...string) (*Result, error) {
+	current, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{
+		Zone:  zone,
+		ID:    id,
+		Diffs: Compare(current, desired, ignoreFields...),
+	}, nil
+}
+
+var indexPattern = regexp.MustCompile(`\[[^\]]*\]`)
+
+// Compare currentとdesiredをフィールドごとに比較し差分を返す
+//
+// ignoreFieldsにはインデックスを除いたフィールドのパス(例: Disks.ServerID)を指定する。
+// 比較ルールは以下の通り:
+//
+//   - ポインタ/インターフェースは参照先の値を比較する
+//   - nilと空のスライス/マップは同一とみなす
+//   - スライスは要素数が異なる場合はスライス全体を、同じ場合は要素ごとに比較する
+//   - types.Tagsは順序を無視して比較する
+//   - マップはdesiredに含まれるキーのみ比較する
+func Compare(current, desired interface{}, ignoreFields ...string) []*Diff {
+	c := &comparer{ignore: make(map[string]bool)}
+	for _, f := range ignoreFields {
+		c.ignore[f] = true
+	}
+	c.compare("", reflect.ValueOf(current), reflect.ValueOf(desired))
+	return c.diffs
+}
+
+type comparer struct {
+	ignore map[string]bool
+	diffs  []*Diff
+}
+
+func (c *comparer) isIgnored(path string) bool {
+	return c.ignore[indexPattern.ReplaceAllString(path, "")]
+}
+
+func (c *comparer) addDiff(path string, current, desired reflect.Value) {
+	c.diffs = append(c.diffs, &Diff{
+		Path:    path,
+		Current: valueInterface(current),
+		Desired: valueInterface(desired),
+	})
+}
+
+func (c *comparer) compare(path string, current, desired reflect.Value) {
+	if path != "" && c.isIgnored(path) {
+		return
+	}
+
+	current = indirect(current)
+	desired = indirect(desired)
+
+	if isEmpty(current) && isEmpty(desired) {
+		return
+	}
+	if !current.IsValid() || !desired.IsValid() || current.Type() != desired.Type() {
+		c.addDiff(path, current, desired)
+		return
+	}
+
+	switch desired.Kind() {
+	case reflect.Struct:
+		if !hasExportedField(desired.Type()) {
+			if !reflect.DeepEqual(current.Interface(), desired.Interface()) {
+				c.addDiff(path, current, desired)
+			}
+			return
+		}
+		for i := 0; i < desired.NumField(); i++ {
+			f := desired.Type().Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			c.compare(joinPath(path, f.Name), current.Field(i), desired.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		if desired.Type() == reflect.TypeOf(types.Tags{}) {
+			if !tagsEqual(current.Interface().(types.Tags), desired.Interface().(types.Tags)) {
+				c.addDiff(path, current, desired)
+			}
+			return
+		}
+		if current.Len() != desired.Len() {
+			c.addDiff(path, current, desired)
+			return
+		}
+		for i := 0; i < desired.Len(); i++ {
+			c.compare(fmt.Sprintf("%s[%d]", path, i), current.Index(i), desired.Index(i))
+		}
+	case reflect.Map:
+		keys := desired.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			var cv reflect.Value
+			if !current.IsNil() {
+				cv = current.MapIndex(key)
+			}
+			c.compare(fmt.Sprintf("%s[%v]", path, key.Interface()), cv, desired.MapIndex(key))
+		}
+	default:
+		if !reflect.DeepEqual(current.Interface(), desired.Interface()) {
+			c.addDiff(path, current, desired)
+		}
+	}
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func isEmpty(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return false
+}
+
+func valueInterface(v reflect.Value) interface{} {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func hasExportedField(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+func joinPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func tagsEqual(current, desired types.Tags) bool {
+	if len(current) != len(desired) {
+		return false
+	}
+	c := append([]string{}, current...)
+	d := append([]string{}, desired...)
+	sort.Strings(c)
+	sort.Strings(d)
+	return strings.Join(c, "\x00") == strings.Join(d, "\x00")
+}
@@ -0,0 +1,176 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+type testNIC struct {
+	Upstream string
+}
+
+type testRequest struct {
+	ID         types.ID
+	Name       string
+	Tags       types.Tags
+	NICs       []*testNIC
+	Setting    interface{}
+	Parameters map[string]interface{}
+	NoWait     bool
+}
+
+func TestCompare(t *testing.T) {
+	current := &testRequest{
+		ID:   types.ID(1),
+		Name: "current",
+		Tags: types.Tags{"tag1", "tag2"},
+		NICs: []*testNIC{
+			{Upstream: "shared"},
+			{Upstream: "111111111111"},
+		},
+		Setting:    &testNIC{Upstream: "shared"},
+		Parameters: map[string]interface{}{"foo": "1", "bar": "2"},
+	}
+
+	cases := []struct {
+		name    string
+		desired *testRequest
+		ignore  []string
+		expect  []*Diff
+	}{
+		{
+			name: "no drift",
+			desired: &testRequest{
+				Name: "current",
+				Tags: types.Tags{"tag2", "tag1"},
+				NICs: []*testNIC{
+					{Upstream: "shared"},
+					{Upstream: "111111111111"},
+				},
+				Setting:    &testNIC{Upstream: "shared"},
+				Parameters: map[string]interface{}{"foo": "1"},
+				NoWait:     true,
+			},
+			ignore: []string{"ID", "NoWait"},
+		},
+		{
+			name: "with drift",
+			desired: &testRequest{
+				Name: "desired",
+				Tags: types.Tags{"tag1"},
+				NICs: []*testNIC{
+					{Upstream: "shared"},
+					{Upstream: "222222222222"},
+				},
+				Setting:    &testNIC{Upstream: "disconnected"},
+				Parameters: map[string]interface{}{"foo": "2"},
+			},
+			ignore: []string{"ID", "NoWait"},
+			expect: []*Diff{
+				{Path: "Name", Current: "current", Desired: "desired"},
+				{Path: "Tags", Current: types.Tags{"tag1", "tag2"}, Desired: types.Tags{"tag1"}},
+				{Path: "NICs[1].Upstream", Current: "111111111111", Desired: "222222222222"},
+				{Path: "Setting.Upstream", Current: "shared", Desired: "disconnected"},
+				{Path: "Parameters[foo]", Current: "1", Desired: "2"},
+			},
+		},
+		{
+			name: "not ignored",
+			desired: &testRequest{
+				Name:       "current",
+				Tags:       types.Tags{"tag1", "tag2"},
+				NICs:       []*testNIC{{Upstream: "shared"}, {Upstream: "111111111111"}},
+				Setting:    &testNIC{Upstream: "shared"},
+				Parameters: map[string]interface{}{"foo": "1"},
+				NoWait:     true,
+			},
+			expect: []*Diff{
+				{Path: "ID", Current: types.ID(1), Desired: types.ID(0)},
+				{Path: "NoWait", Current: false, Desired: true},
+			},
+		},
+		{
+			name: "ignore nested fields",
+			desired: &testRequest{
+				Name: "current",
+				Tags: types.Tags{"tag1", "tag2"},
+				NICs: []*testNIC{
+					{Upstream: "disconnected"},
+					{Upstream: "disconnected"},
+				},
+				Setting:    &testNIC{Upstream: "shared"},
+				Parameters: map[string]interface{}{"foo": "1"},
+			},
+			ignore: []string{"ID", "NICs.Upstream"},
+		},
+		{
+			name: "ignore nested fields does not ignore parent",
+			desired: &testRequest{
+				Name:       "current",
+				Tags:       types.Tags{"tag1", "tag2"},
+				NICs:       []*testNIC{{Upstream: "disconnected"}},
+				Setting:    &testNIC{Upstream: "shared"},
+				Parameters: map[string]interface{}{"foo": "1"},
+			},
+			ignore: []string{"ID", "NICs.Upstream"},
+			expect: []*Diff{
+				{Path: "NICs", Current: current.NICs, Desired: []*testNIC{{Upstream: "disconnected"}}},
+			},
+		},
+		{
+			name: "ignore map keys",
+			desired: &testRequest{
+				Name:       "current",
+				Tags:       types.Tags{"tag1", "tag2"},
+				NICs:       []*testNIC{{Upstream: "shared"}, {Upstream: "111111111111"}},
+				Setting:    &testNIC{Upstream: "shared"},
+				Parameters: map[string]interface{}{"foo": "2"},
+			},
+			ignore: []string{"ID", "Parameters"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expect, Compare(current, tc.desired, tc.ignore...))
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	current := &testRequest{ID: types.ID(1), Name: "current"}
+	load := func(context.Context) (*testRequest, error) { return current, nil }
+
+	result, err := Detect(context.Background(), "is1a", types.ID(1), &testRequest{Name: "current"}, load, "ID")
+	require.NoError(t, err)
+	require.Equal(t, "is1a", result.Zone)
+	require.Equal(t, types.ID(1), result.ID)
+	require.False(t, result.HasDrift())
+
+	result, err = Detect(context.Background(), "is1a", types.ID(1), &testRequest{Name: "desired"}, load, "ID")
+	require.NoError(t, err)
+	require.True(t, result.HasDrift())
+
+	_, err = Detect(context.Background(), "is1a", types.ID(1), &testRequest{}, func(context.Context) (*testRequest, error) {
+		return nil, errors.New("not found")
+	})
+	require.Error(t, err)
+}
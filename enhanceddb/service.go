@@ -14,7 +14,11 @@
 
 package enhanceddb
 
-import "github.com/sacloud/iaas-api-go"
+import (
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/enhanceddb/builder"
+)
 
 // Service provides a high-level API of for EnhancedDB
 type Service struct {
@@ -25,3 +29,13 @@ type Service struct {
 func New(caller iaas.APICaller) *Service {
 	return &Service{caller: caller}
 }
+
+var (
+	_ service.Creator[*CreateRequest, *builder.EnhancedDB] = (*Service)(nil)
+	_ service.Reader[*ReadRequest, *builder.EnhancedDB]    = (*Service)(nil)
+	_ service.Updater[*UpdateRequest, *builder.EnhancedDB] = (*Service)(nil)
+	_ service.Deleter[*DeleteRequest]                      = (*Service)(nil)
+	_ service.Finder[*FindRequest, *iaas.EnhancedDB]       = (*Service)(nil)
+	_ service.FindIterator[*FindRequest, *iaas.EnhancedDB] = (*Service)(nil)
+	_ service.Applier[*ApplyRequest, *builder.EnhancedDB]  = (*Service)(nil)
+)
@@ -14,7 +14,10 @@
 
 package ipaddress
 
-import "github.com/sacloud/iaas-api-go"
+import (
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
 
 // Service provides a high-level API of for IPAddress
 type Service struct {
@@ -25,3 +28,7 @@ type Service struct {
 func New(caller iaas.APICaller) *Service {
 	return &Service{caller: caller}
 }
+
+var (
+	_ service.Reader[*ReadRequest, *iaas.IPAddress] = (*Service)(nil)
+)
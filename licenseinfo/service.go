@@ -14,7 +14,10 @@
 
 package licenseinfo
 
-import "github.com/sacloud/iaas-api-go"
+import (
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
 
 // Service provides a high-level API of for LicenseInfo
 type Service struct {
@@ -25,3 +28,9 @@ type Service struct {
 func New(caller iaas.APICaller) *Service {
 	return &Service{caller: caller}
 }
+
+var (
+	_ service.Reader[*ReadRequest, *iaas.LicenseInfo]       = (*Service)(nil)
+	_ service.Finder[*FindRequest, *iaas.LicenseInfo]       = (*Service)(nil)
+	_ service.FindIterator[*FindRequest, *iaas.LicenseInfo] = (*Service)(nil)
+)
@@ -0,0 +1,47 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancer
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-service-go/drift"
+)
+
+// driftIgnoreFields ロードバランサのDriftで比較しないフィールド
+var driftIgnoreFields = []string{
+	"Zone",
+	"ID",
+	// 更新のたびに変わる楽観的排他制御用の値であり、設定内容ではないため
+	"SettingsHash",
+	// Apply時の待機方法の指定であり、リソースの状態ではないため
+	"NoWait",
+}
+
+// Drift 現在のリソースとDesiredをフィールドごとに比較し差分を返す
+func (s *Service) Drift(req *DriftRequest) (*drift.Result, error) {
+	return s.DriftWithContext(context.Background(), req)
+}
+
+// DriftWithContext 現在のリソースとDesiredをフィールドごとに比較し差分を返す
+func (s *Service) DriftWithContext(ctx context.Context, req *DriftRequest) (*drift.Result, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	return drift.Detect(ctx, req.Zone, req.ID, req.Desired, func(ctx context.Context) (*ApplyRequest, error) {
+		return (&UpdateRequest{Zone: req.Zone, ID: req.ID}).ApplyRequest(ctx, s.caller)
+	}, driftIgnoreFields...)
+}
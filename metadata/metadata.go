@@ -0,0 +1,98 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"reflect"
+)
+
+// Scope リソースのスコープ
+type Scope string
+
+const (
+	// ScopeZone ゾーンごとのリソース
+	ScopeZone = Scope("zone")
+	// ScopeGlobal グローバルリソース
+	ScopeGlobal = Scope("global")
+)
+
+// Resource サービスパッケージが提供するリソースのメタデータ
+type Resource struct {
+	Name        string // リソース名(例: Server)
+	PackageName string // パッケージ名(例: server)
+	PackagePath string // パッケージのインポートパス
+	Scope       Scope  // いずれかの操作がゾーン指定を必要とする場合ScopeZone
+	Operations  []*Operation
+}
+
+// Operation 操作のメタデータ
+//
+// Service.XxxWithContextメソッドを元に作成される
+type Operation struct {
+	Name        string       // 操作名(例: Create)
+	Scope       Scope        // リクエストにZoneフィールドを持つ場合ScopeZone
+	RequestType reflect.Type // リクエストの型(ポインタではなく構造体の型)、リクエストを受け取らない操作の場合はnil
+	ResultType  reflect.Type // 戻り値の型、errorのみを返す操作の場合はnil
+	Fields      []*Field     // リクエストのフィールド
+}
+
+// RequestTypeName リクエストの型名を返す(例: server.CreateRequest)
+func (o *Operation) RequestTypeName() string {
+	if o.RequestType == nil {
+		return ""
+	}
+	return o.RequestType.String()
+}
+
+// ResultTypeName 戻り値の型名を返す(例: *iaas.Server)
+func (o *Operation) ResultTypeName() string {
+	if o.ResultType == nil {
+		return ""
+	}
+	return o.ResultType.String()
+}
+
+// Field リクエストのフィールドのメタデータ
+type Field struct {
+	Name     string
+	Type     reflect.Type
+	Validate string // validateタグの値
+	Service  string // serviceタグの値
+}
+
+// TypeName フィールドの型名を返す(例: types.ID)
+func (f *Field) TypeName() string {
+	return f.Type.String()
+}
+
+// Operation 名前を指定して操作のメタデータを返す
+func (r *Resource) Operation(name string) *Operation {
+	for _, op := range r.Operations {
+		if op.Name == name {
+			return op
+		}
+	}
+	return nil
+}
+
+// Field 名前を指定してフィールドのメタデータを返す
+func (o *Operation) Field(name string) *Field {
+	for _, f := range o.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package metadata
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sacloud/iaas-service-go/archive"
+	"github.com/sacloud/iaas-service-go/authstatus"
+	"github.com/sacloud/iaas-service-go/autobackup"
+	"github.com/sacloud/iaas-service-go/autoscale"
+	"github.com/sacloud/iaas-service-go/bill"
+	"github.com/sacloud/iaas-service-go/bridge"
+	"github.com/sacloud/iaas-service-go/cdrom"
+	"github.com/sacloud/iaas-service-go/certificateauthority"
+	"github.com/sacloud/iaas-service-go/containerregistry"
+	"github.com/sacloud/iaas-service-go/coupon"
+	"github.com/sacloud/iaas-service-go/database"
+	"github.com/sacloud/iaas-service-go/disk"
+	"github.com/sacloud/iaas-service-go/diskplan"
+	"github.com/sacloud/iaas-service-go/dns"
+	"github.com/sacloud/iaas-service-go/enhanceddb"
+	"github.com/sacloud/iaas-service-go/esme"
+	"github.com/sacloud/iaas-service-go/gslb"
+	"github.com/sacloud/iaas-service-go/icon"
+	"github.com/sacloud/iaas-service-go/iface"
+	"github.com/sacloud/iaas-service-go/internet"
+	"github.com/sacloud/iaas-service-go/internetplan"
+	"github.com/sacloud/iaas-service-go/ipaddress"
+	"github.com/sacloud/iaas-service-go/ipv6addr"
+	"github.com/sacloud/iaas-service-go/ipv6net"
+	"github.com/sacloud/iaas-service-go/license"
+	"github.com/sacloud/iaas-service-go/licenseinfo"
+	"github.com/sacloud/iaas-service-go/loadbalancer"
+	"github.com/sacloud/iaas-service-go/localrouter"
+	"github.com/sacloud/iaas-service-go/mobilegateway"
+	"github.com/sacloud/iaas-service-go/nfs"
+	"github.com/sacloud/iaas-service-go/note"
+	"github.com/sacloud/iaas-service-go/packetfilter"
+	"github.com/sacloud/iaas-service-go/privatehost"
+	"github.com/sacloud/iaas-service-go/privatehostplan"
+	"github.com/sacloud/iaas-service-go/proxylb"
+	"github.com/sacloud/iaas-service-go/region"
+	"github.com/sacloud/iaas-service-go/server"
+	"github.com/sacloud/iaas-service-go/serverplan"
+	"github.com/sacloud/iaas-service-go/serviceclass"
+	"github.com/sacloud/iaas-service-go/sim"
+	"github.com/sacloud/iaas-service-go/simplemonitor"
+	"github.com/sacloud/iaas-service-go/sshkey"
+	"github.com/sacloud/iaas-service-go/stack"
+	"github.com/sacloud/iaas-service-go/subnet"
+	"github.com/sacloud/iaas-service-go/swytch"
+	"github.com/sacloud/iaas-service-go/vpcrouter"
+	"github.com/sacloud/iaas-service-go/zone"
+)
+
+var services = []struct {
+	name    string
+	service interface{}
+}{
+	{name: "Archive", service: (*archive.Service)(nil)},
+	{name: "AuthStatus", service: (*authstatus.Service)(nil)},
+	{name: "AutoBackup", service: (*autobackup.Service)(nil)},
+	{name: "AutoScale", service: (*autoscale.Service)(nil)},
+	{name: "Bill", service: (*bill.Service)(nil)},
+	{name: "Bridge", service: (*bridge.Service)(nil)},
+	{name: "CDROM", service: (*cdrom.Service)(nil)},
+	{name: "CertificateAuthority", service: (*certificateauthority.Service)(nil)},
+	{name: "ContainerRegistry", service: (*containerregistry.Service)(nil)},
+	{name: "Coupon", service: (*coupon.Service)(nil)},
+	{name: "Database", service: (*database.Service)(nil)},
+	{name: "Disk", service: (*disk.Service)(nil)},
+	{name: "DiskPlan", service: (*diskplan.Service)(nil)},
+	{name: "DNS", service: (*dns.Service)(nil)},
+	{name: "EnhancedDB", service: (*enhanceddb.Service)(nil)},
+	{name: "ESME", service: (*esme.Service)(nil)},
+	{name: "GSLB", service: (*gslb.Service)(nil)},
+	{name: "Icon", service: (*icon.Service)(nil)},
+	{name: "Interface", service: (*iface.Service)(nil)},
+	{name: "Internet", service: (*internet.Service)(nil)},
+	{name: "InternetPlan", service: (*internetplan.Service)(nil)},
+	{name: "IPAddress", service: (*ipaddress.Service)(nil)},
+	{name: "IPv6Addr", service: (*ipv6addr.Service)(nil)},
+	{name: "IPv6Net", service: (*ipv6net.Service)(nil)},
+	{name: "License", service: (*license.Service)(nil)},
+	{name: "LicenseInfo", service: (*licenseinfo.Service)(nil)},
+	{name: "LoadBalancer", service: (*loadbalancer.Service)(nil)},
+	{name: "LocalRouter", service: (*localrouter.Service)(nil)},
+	{name: "MobileGateway", service: (*mobilegateway.Service)(nil)},
+	{name: "NFS", service: (*nfs.Service)(nil)},
+	{name: "Note", service: (*note.Service)(nil)},
+	{name: "PacketFilter", service: (*packetfilter.Service)(nil)},
+	{name: "PrivateHost", service: (*privatehost.Service)(nil)},
+	{name: "PrivateHostPlan", service: (*privatehostplan.Service)(nil)},
+	{name: "ProxyLB", service: (*proxylb.Service)(nil)},
+	{name: "Region", service: (*region.Service)(nil)},
+	{name: "Server", service: (*server.Service)(nil)},
+	{name: "ServerPlan", service: (*serverplan.Service)(nil)},
+	{name: "ServiceClass", service: (*serviceclass.Service)(nil)},
+	{name: "SIM", service: (*sim.Service)(nil)},
+	{name: "SimpleMonitor", service: (*simplemonitor.Service)(nil)},
+	{name: "SSHKey", service: (*sshkey.Service)(nil)},
+	{name: "Stack", service: (*stack.Service)(nil)},
+	{name: "Subnet", service: (*subnet.Service)(nil)},
+	{name: "Switch", service: (*swytch.Service)(nil)},
+	{name: "VPCRouter", service: (*vpcrouter.Service)(nil)},
+	{name: "Zone", service: (*zone.Service)(nil)},
+}
+
+var (
+	resources     []*Resource
+	resourcesOnce sync.Once
+)
+
+// Resources 全リソースのメタデータを返す
+func Resources() []*Resource {
+	resourcesOnce.Do(func() {
+		for _, s := range services {
+			resources = append(resources, newResource(s.name, reflect.TypeOf(s.service)))
+		}
+		sort.Slice(resources, func(i, j int) bool { return resources[i].Name < resources[j].Name })
+	})
+	return resources
+}
+
+// Lookup リソース名またはパッケージ名を指定してリソースのメタデータを返す
+func Lookup(name string) *Resource {
+	for _, r := range Resources() {
+		if r.Name == name || r.PackageName == name {
+			return r
+		}
+	}
+	return nil
+}
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+const methodSuffix = "WithContext"
+
+func newResource(name string, serviceType reflect.Type) *Resource {
+	pkgPath := serviceType.Elem().PkgPath()
+	resource := &Resource{
+		Name:        name,
+		PackageName: pkgPath[strings.LastIndex(pkgPath, "/")+1:],
+		PackagePath: pkgPath,
+		Scope:       ScopeGlobal,
+	}
+
+	for i := 0; i < serviceType.NumMethod(); i++ {
+		op := newOperation(serviceType.Method(i))
+		if op == nil {
+			continue
+		}
+		if op.Scope == ScopeZone {
+			resource.Scope = ScopeZone
+		}
+		resource.Operations = append(resource.Operations, op)
+	}
+	return resource
+}
+
+// newOperation XxxWithContext(ctx context.Context[, req *XxxRequest]) ([T, ]error)の形式のメソッドから操作のメタデータを作成する
+//
+// 形式が異なる場合はnilを返す
+func newOperation(method reflect.Method) *Operation {
+	if !strings.HasSuffix(method.Name, methodSuffix) || method.Name == methodSuffix {
+		return nil
+	}
+
+	// 0番目はレシーバ
+	t := method.Type
+	if t.NumIn() < 2 || t.NumIn() > 3 || t.In(1) != contextType {
+		return nil
+	}
+	if t.NumOut() < 1 || t.NumOut() > 2 || t.Out(t.NumOut()-1) != errorType {
+		return nil
+	}
+
+	op := &Operation{
+		Name:  strings.TrimSuffix(method.Name, methodSuffix),
+		Scope: ScopeGlobal,
+	}
+	if t.NumOut() == 2 {
+		op.ResultType = t.Out(0)
+	}
+	if t.NumIn() == 3 {
+		reqType := t.In(2)
+		if reqType.Kind() == reflect.Ptr {
+			reqType = reqType.Elem()
+		}
+		if reqType.Kind() != reflect.Struct {
+			return nil
+		}
+		op.RequestType = reqType
+		op.Fields = fields(reqType)
+		if _, ok := reqType.FieldByName("Zone"); ok {
+			op.Scope = ScopeZone
+		}
+	}
+	return op
+}
+
+func fields(t reflect.Type) []*Field {
+	var results []*Field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		results = append(results, &Field{
+			Name:     f.Name,
+			Type:     f.Type,
+			Validate: f.Tag.Get("validate"),
+			Service:  f.Tag.Get("service"),
+		})
+	}
+	return results
+}
@@ -0,0 +1,73 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResources(t *testing.T) {
+	require.Len(t, Resources(), len(services))
+
+	t.Run("zoned resource", func(t *testing.T) {
+		server := Lookup("Server")
+		require.NotNil(t, server)
+		require.Equal(t, "server", server.PackageName)
+		require.Equal(t, "github.com/sacloud/iaas-service-go/server", server.PackagePath)
+		require.Equal(t, ScopeZone, server.Scope)
+
+		create := server.Operation("Create")
+		require.NotNil(t, create)
+		require.Equal(t, ScopeZone, create.Scope)
+		require.Equal(t, "server.CreateRequest", create.RequestTypeName())
+		require.Equal(t, "*iaas.Server", create.ResultTypeName())
+
+		name := create.Field("Name")
+		require.NotNil(t, name)
+		require.Equal(t, "string", name.TypeName())
+		require.Equal(t, "required", name.Validate)
+
+		zone := create.Field("Zone")
+		require.NotNil(t, zone)
+		require.Equal(t, "-", zone.Service)
+
+		for _, name := range []string{"Read", "Update", "Delete", "Find", "Apply", "Boot", "MonitorCPU"} {
+			require.NotNil(t, server.Operation(name), name)
+		}
+	})
+
+	t.Run("global resource", func(t *testing.T) {
+		dns := Lookup("dns")
+		require.NotNil(t, dns)
+		require.Equal(t, ScopeGlobal, dns.Scope)
+
+		del := dns.Operation("Delete")
+		require.NotNil(t, del)
+		require.Equal(t, ScopeGlobal, del.Scope)
+		require.Nil(t, del.ResultType)
+	})
+
+	t.Run("operation without request", func(t *testing.T) {
+		authStatus := Lookup("AuthStatus")
+		require.NotNil(t, authStatus)
+
+		read := authStatus.Operation("Read")
+		require.NotNil(t, read)
+		require.Nil(t, read.RequestType)
+		require.Empty(t, read.Fields)
+	})
+}
@@ -19,7 +19,6 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
-	"time"
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/accessor"
@@ -221,7 +220,9 @@ func (b *Builder) create(ctx context.Context, zone string) (*iaas.MobileGateway,
 			}
 
 			// [HACK] スイッチ接続直後だとエラーになることがあるため数秒待つ
-			time.Sleep(b.SetupOptions.NICUpdateWaitDuration)
+			if err := setup2.Sleep(ctx, b.SetupOptions.NICUpdateWaitDuration); err != nil {
+				return err
+			}
 
 			// Interface設定
 			updated, err := b.Client.MobileGateway.UpdateSettings(ctx, zone, id, &iaas.MobileGatewayUpdateSettingsRequest{
@@ -366,7 +367,9 @@ func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.M
 					return nil, err
 				}
 				// [HACK] スイッチ接続直後だとエラーになることがあるため数秒待つ
-				time.Sleep(b.SetupOptions.NICUpdateWaitDuration)
+				if err := setup2.Sleep(ctx, b.SetupOptions.NICUpdateWaitDuration); err != nil {
+					return nil, err
+				}
 
 				updated, err := b.Client.MobileGateway.UpdateSettings(ctx, zone, id, &iaas.MobileGatewayUpdateSettingsRequest{
 					InternetConnectionEnabled:       types.StringFlag(b.InternetConnectionEnabled),
@@ -394,7 +397,9 @@ func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.M
 				}
 
 				// [HACK] スイッチ接続直後だとエラーになることがあるため数秒待つ
-				time.Sleep(b.SetupOptions.NICUpdateWaitDuration)
+				if err := setup2.Sleep(ctx, b.SetupOptions.NICUpdateWaitDuration); err != nil {
+					return nil, err
+				}
 			}
 
 			// Interface設定
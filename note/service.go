@@ -14,7 +14,10 @@
 
 package note
 
-import "github.com/sacloud/iaas-api-go"
+import (
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
 
 // Service provides a high-level API of for Note
 type Service struct {
@@ -25,3 +28,12 @@ type Service struct {
 func New(caller iaas.APICaller) *Service {
 	return &Service{caller: caller}
 }
+
+var (
+	_ service.Creator[*CreateRequest, *iaas.Note]    = (*Service)(nil)
+	_ service.Reader[*ReadRequest, *iaas.Note]       = (*Service)(nil)
+	_ service.Updater[*UpdateRequest, *iaas.Note]    = (*Service)(nil)
+	_ service.Deleter[*DeleteRequest]                = (*Service)(nil)
+	_ service.Finder[*FindRequest, *iaas.Note]       = (*Service)(nil)
+	_ service.FindIterator[*FindRequest, *iaas.Note] = (*Service)(nil)
+)
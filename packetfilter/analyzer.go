@@ -0,0 +1,208 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetfilter
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// WarningType Analyzeが検出する問題の種類
+type WarningType string
+
+const (
+	// WarningUnreachable 前にある全てのパケットにマッチするルールにより到達しないルール
+	WarningUnreachable WarningType = "unreachable"
+	// WarningDuplicate 前にあるルールと同一のルール
+	WarningDuplicate WarningType = "duplicate"
+	// WarningShadowed 前にある異なる動作のルールに包含され、意図した動作とならないルール
+	WarningShadowed WarningType = "shadowed"
+	// WarningRedundant 前にある同じ動作のルールに包含され、不要なルール
+	WarningRedundant WarningType = "redundant"
+	// WarningMissingFinalDeny 最後に全てのパケットを拒否するルールがない
+	WarningMissingFinalDeny WarningType = "missing_final_deny"
+	// WarningOverPermissive 任意の送信元から重要なポートへの通信を許可するルール
+	WarningOverPermissive WarningType = "over_permissive"
+)
+
+// SensitivePorts WarningOverPermissiveの判定に用いるポート番号
+var SensitivePorts = []int{
+	22,    // SSH
+	23,    // Telnet
+	445,   // SMB
+	1433,  // SQL Server
+	2375,  // Docker
+	3306,  // MySQL
+	3389,  // RDP
+	5432,  // PostgreSQL
+	5900,  // VNC
+	6379,  // Redis
+	9200,  // Elasticsearch
+	11211, // memcached
+	27017, // MongoDB
+}
+
+// Warning Analyzeが検出した問題
+type Warning struct {
+	Type         WarningType
+	Index        int // 問題のあるルールのインデックス、フィルタ全体に対する問題の場合は-1
+	RelatedIndex int // 原因となったルールのインデックス、存在しない場合は-1
+	Message      string
+}
+
+func (w *Warning) String() string {
+	if w.Index < 0 {
+		return fmt.Sprintf("%s: %s", w.Type, w.Message)
+	}
+	return fmt.Sprintf("expression[%d]: %s: %s", w.Index, w.Type, w.Message)
+}
+
+// AnalyzeFilter パケットフィルタのルールを解析し、問題を返す
+func AnalyzeFilter(filter *iaas.PacketFilter) ([]*Warning, error) {
+	return Analyze(filter.Expression)
+}
+
+// Analyze ルールを解析し、到達しないルールや重複したルール、過剰に許可しているルールなどを検出する
+func Analyze(expressions []*iaas.PacketFilterExpression) ([]*Warning, error) {
+	rules, err := parseExpressions(expressions)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rules {
+		if r.network != nil && isAnyNetwork(r.network) {
+			r.network = nil
+		}
+	}
+
+	var warnings []*Warning
+	for j, r := range rules {
+		if w := analyzeReachability(rules, j); w != nil {
+			warnings = append(warnings, w)
+		}
+		if w := analyzePermission(r, j); w != nil {
+			warnings = append(warnings, w)
+		}
+	}
+
+	if len(rules) == 0 || !isFinalDeny(rules[len(rules)-1]) {
+		warnings = append(warnings, &Warning{
+			Type:         WarningMissingFinalDeny,
+			Index:        -1,
+			RelatedIndex: -1,
+			Message:      "last expression should deny all packets (protocol ip, action deny); unmatched packets are allowed",
+		})
+	}
+	return warnings, nil
+}
+
+func analyzeReachability(rules []*rule, j int) *Warning {
+	r := rules[j]
+	for i := 0; i < j; i++ {
+		prev := rules[i]
+		if !prev.covers(r) {
+			continue
+		}
+
+		w := &Warning{Index: j, RelatedIndex: i}
+		switch {
+		case prev.matchesAll():
+			w.Type = WarningUnreachable
+			w.Message = fmt.Sprintf("unreachable because expression[%d] matches all packets with action %s", i, prev.action)
+		case prev.equals(r):
+			w.Type = WarningDuplicate
+			w.Message = fmt.Sprintf("duplicate of expression[%d]", i)
+		case prev.action != r.action:
+			w.Type = WarningShadowed
+			w.Message = fmt.Sprintf("shadowed by expression[%d], packets are always handled with action %s", i, prev.action)
+		default:
+			w.Type = WarningRedundant
+			w.Message = fmt.Sprintf("redundant because expression[%d] already matches all of its packets", i)
+		}
+		return w
+	}
+	return nil
+}
+
+func analyzePermission(r *rule, index int) *Warning {
+	if r.action != types.Actions.Allow || r.network != nil {
+		return nil
+	}
+	if r.protocol != types.Protocols.IP && !hasPort(r.protocol) {
+		return nil
+	}
+
+	var ports []string
+	for _, port := range SensitivePorts {
+		if r.destinationPort == nil || r.destinationPort.contains(port) {
+			ports = append(ports, fmt.Sprintf("%d", port))
+		}
+	}
+	if len(ports) == 0 {
+		return nil
+	}
+	return &Warning{
+		Type:         WarningOverPermissive,
+		Index:        index,
+		RelatedIndex: -1,
+		Message:      fmt.Sprintf("allows %s from any source to sensitive ports: %s", r.protocol, strings.Join(ports, ",")),
+	}
+}
+
+func isAnyNetwork(network *addrRange) bool {
+	return network.from == netip.IPv4Unspecified() && network.to == netip.AddrFrom4([4]byte{255, 255, 255, 255})
+}
+
+func isFinalDeny(r *rule) bool {
+	return r.matchesAll() && r.action == types.Actions.Deny
+}
+
+// matchesAll 全てのパケットにマッチするか
+func (r *rule) matchesAll() bool {
+	return r.protocol == types.Protocols.IP && r.network == nil
+}
+
+// covers otherにマッチするパケットが常にrにもマッチするか
+func (r *rule) covers(other *rule) bool {
+	switch r.protocol {
+	case types.Protocols.IP:
+	case types.Protocols.Fragment:
+		if other.protocol != types.Protocols.Fragment {
+			return false
+		}
+	default:
+		if other.protocol != r.protocol {
+			return false
+		}
+	}
+
+	if r.network != nil && (other.network == nil || !r.network.covers(other.network)) {
+		return false
+	}
+	if r.sourcePort != nil && (other.sourcePort == nil || !r.sourcePort.covers(other.sourcePort)) {
+		return false
+	}
+	if r.destinationPort != nil && (other.destinationPort == nil || !r.destinationPort.covers(other.destinationPort)) {
+		return false
+	}
+	return true
+}
+
+func (r *rule) equals(other *rule) bool {
+	return r.action == other.action && r.covers(other) && other.covers(r)
+}
@@ -0,0 +1,98 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetfilter
+
+import (
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze(t *testing.T) {
+	cases := []struct {
+		msg         string
+		expressions []*iaas.PacketFilterExpression
+		expect      []*Warning
+	}{
+		{
+			msg:         "no warnings",
+			expressions: testExpressions,
+			expect:      nil,
+		},
+		{
+			msg: "duplicate, shadowed and redundant",
+			expressions: []*iaas.PacketFilterExpression{
+				{Protocol: types.Protocols.TCP, SourceNetwork: "192.0.2.0/24", DestinationPort: "80", Action: types.Actions.Allow},
+				{Protocol: types.Protocols.TCP, SourceNetwork: "192.0.2.0/255.255.255.0", DestinationPort: "80", Action: types.Actions.Allow},
+				{Protocol: types.Protocols.TCP, SourceNetwork: "192.0.2.10", DestinationPort: "80", Action: types.Actions.Deny},
+				{Protocol: types.Protocols.TCP, SourceNetwork: "192.0.2.128/25", DestinationPort: "80", Action: types.Actions.Allow},
+				{Protocol: types.Protocols.TCP, SourceNetwork: "198.51.100.0/24", DestinationPort: "80", Action: types.Actions.Allow},
+				{Protocol: types.Protocols.IP, Action: types.Actions.Deny},
+			},
+			expect: []*Warning{
+				{Type: WarningDuplicate, Index: 1, RelatedIndex: 0, Message: "duplicate of expression[0]"},
+				{Type: WarningShadowed, Index: 2, RelatedIndex: 0, Message: "shadowed by expression[0], packets are always handled with action allow"},
+				{Type: WarningRedundant, Index: 3, RelatedIndex: 0, Message: "redundant because expression[0] already matches all of its packets"},
+			},
+		},
+		{
+			msg: "unreachable and missing final deny",
+			expressions: []*iaas.PacketFilterExpression{
+				{Protocol: types.Protocols.IP, SourceNetwork: "0.0.0.0/0", Action: types.Actions.Allow},
+				{Protocol: types.Protocols.TCP, SourceNetwork: "192.0.2.0/24", DestinationPort: "80", Action: types.Actions.Deny},
+			},
+			expect: []*Warning{
+				{Type: WarningOverPermissive, Index: 0, RelatedIndex: -1, Message: "allows ip from any source to sensitive ports: 22,23,445,1433,2375,3306,3389,5432,5900,6379,9200,11211,27017"},
+				{Type: WarningUnreachable, Index: 1, RelatedIndex: 0, Message: "unreachable because expression[0] matches all packets with action allow"},
+				{Type: WarningMissingFinalDeny, Index: -1, RelatedIndex: -1, Message: "last expression should deny all packets (protocol ip, action deny); unmatched packets are allowed"},
+			},
+		},
+		{
+			msg: "over permissive",
+			expressions: []*iaas.PacketFilterExpression{
+				{Protocol: types.Protocols.TCP, DestinationPort: "22", Action: types.Actions.Allow},
+				{Protocol: types.Protocols.TCP, DestinationPort: "3000-4000", Action: types.Actions.Allow},
+				{Protocol: types.Protocols.TCP, DestinationPort: "443", Action: types.Actions.Allow},
+				{Protocol: types.Protocols.IP, Action: types.Actions.Deny},
+			},
+			expect: []*Warning{
+				{Type: WarningOverPermissive, Index: 0, RelatedIndex: -1, Message: "allows tcp from any source to sensitive ports: 22"},
+				{Type: WarningOverPermissive, Index: 1, RelatedIndex: -1, Message: "allows tcp from any source to sensitive ports: 3306,3389"},
+			},
+		},
+		{
+			msg:         "empty",
+			expressions: nil,
+			expect: []*Warning{
+				{Type: WarningMissingFinalDeny, Index: -1, RelatedIndex: -1, Message: "last expression should deny all packets (protocol ip, action deny); unmatched packets are allowed"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		warnings, err := Analyze(tc.expressions)
+		require.NoError(t, err, tc.msg)
+		require.Equal(t, tc.expect, warnings, tc.msg)
+	}
+}
+
+func TestWarning_String(t *testing.T) {
+	require.Equal(t, "expression[1]: duplicate: duplicate of expression[0]",
+		(&Warning{Type: WarningDuplicate, Index: 1, RelatedIndex: 0, Message: "duplicate of expression[0]"}).String())
+	require.Equal(t, "missing_final_deny: message",
+		(&Warning{Type: WarningMissingFinalDeny, Index: -1, RelatedIndex: -1, Message: "message"}).String())
+}
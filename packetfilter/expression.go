@@ -0,0 +1,208 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetfilter
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// addrRange 送信元ネットワークを表すアドレスの範囲
+type addrRange struct {
+	from, to netip.Addr
+}
+
+func (r *addrRange) contains(addr netip.Addr) bool {
+	return r.from.BitLen() == addr.BitLen() && r.from.Compare(addr) <= 0 && addr.Compare(r.to) <= 0
+}
+
+// covers rがotherを包含するか
+func (r *addrRange) covers(other *addrRange) bool {
+	return r.contains(other.from) && r.contains(other.to)
+}
+
+// portRange ポート番号の範囲
+type portRange struct {
+	from, to int
+}
+
+func (r *portRange) contains(port int) bool {
+	return r.from <= port && port <= r.to
+}
+
+func (r *portRange) covers(other *portRange) bool {
+	return r.from <= other.from && other.to <= r.to
+}
+
+// rule 評価用にパースしたPacketFilterExpression
+//
+// network/sourcePort/destinationPortがnilの場合は全てにマッチする
+type rule struct {
+	protocol        types.Protocol
+	network         *addrRange
+	sourcePort      *portRange
+	destinationPort *portRange
+	action          types.Action
+}
+
+func parseExpressions(expressions []*iaas.PacketFilterExpression) ([]*rule, error) {
+	var rules []*rule
+	for i, e := range expressions {
+		r, err := parseExpression(e)
+		if err != nil {
+			return nil, fmt.Errorf("expression[%d]: %s", i, err)
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+func parseExpression(e *iaas.PacketFilterExpression) (*rule, error) {
+	if e == nil {
+		return nil, fmt.Errorf("expression is nil")
+	}
+
+	r := &rule{protocol: e.Protocol, action: e.Action}
+	switch e.Protocol {
+	case types.Protocols.TCP, types.Protocols.UDP, types.Protocols.ICMP, types.Protocols.Fragment, types.Protocols.IP:
+	default:
+		return nil, fmt.Errorf("invalid protocol: %q", e.Protocol)
+	}
+	switch e.Action {
+	case types.Actions.Allow, types.Actions.Deny:
+	default:
+		return nil, fmt.Errorf("invalid action: %q", e.Action)
+	}
+
+	network, err := parseNetwork(string(e.SourceNetwork))
+	if err != nil {
+		return nil, err
+	}
+	r.network = network
+
+	if hasPort(e.Protocol) {
+		if r.sourcePort, err = parsePortRange(string(e.SourcePort)); err != nil {
+			return nil, err
+		}
+		if r.destinationPort, err = parsePortRange(string(e.DestinationPort)); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// hasPort ポートの指定が有効なプロトコルか
+func hasPort(protocol types.Protocol) bool {
+	return protocol == types.Protocols.TCP || protocol == types.Protocols.UDP
+}
+
+// parseNetwork 送信元ネットワークをパースする
+//
+// A.B.C.D、A.B.C.D/N、A.B.C.D/M.M.M.Mの形式をサポートする。空の場合はnilを返す
+func parseNetwork(v string) (*addrRange, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return nil, nil
+	}
+
+	addrPart, maskPart, hasMask := strings.Cut(v, "/")
+	addr, err := netip.ParseAddr(addrPart)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source network: %q", v)
+	}
+	if !hasMask {
+		return &addrRange{from: addr, to: addr}, nil
+	}
+
+	bits, err := strconv.Atoi(maskPart)
+	if err != nil {
+		mask, perr := netip.ParseAddr(maskPart)
+		if perr != nil || !mask.Is4() || !addr.Is4() {
+			return nil, fmt.Errorf("invalid source network: %q", v)
+		}
+		if bits, err = maskBits(mask); err != nil {
+			return nil, fmt.Errorf("invalid source network: %q", v)
+		}
+	}
+
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source network: %q", v)
+	}
+	return &addrRange{from: prefix.Addr(), to: lastAddr(prefix)}, nil
+}
+
+// maskBits ドット区切り形式のネットマスクをプレフィックス長に変換する
+func maskBits(mask netip.Addr) (int, error) {
+	b := mask.As4()
+	v := uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+	bits := 0
+	for v&0x80000000 != 0 {
+		bits++
+		v <<= 1
+	}
+	if v != 0 {
+		return 0, fmt.Errorf("invalid netmask: %s", mask)
+	}
+	return bits, nil
+}
+
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	b := prefix.Addr().AsSlice()
+	for i := prefix.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 1 << (7 - i%8)
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
+
+// parsePortRange ポートをパースする
+//
+// N、N-Mの形式をサポートする。空の場合はnilを返す
+func parsePortRange(v string) (*portRange, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return nil, nil
+	}
+
+	fromPart, toPart, isRange := strings.Cut(v, "-")
+	from, err := parsePort(fromPart)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port: %q", v)
+	}
+	to := from
+	if isRange {
+		if to, err = parsePort(toPart); err != nil || to < from {
+			return nil, fmt.Errorf("invalid port: %q", v)
+		}
+	}
+	return &portRange{from: from, to: to}, nil
+}
+
+func parsePort(v string) (int, error) {
+	port, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil {
+		return 0, err
+	}
+	if port < 0 || port > 65535 {
+		return 0, fmt.Errorf("port out of range: %d", port)
+	}
+	return port, nil
+}
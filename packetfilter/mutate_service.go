@@ -0,0 +1,45 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetfilter
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-service-go/serviceutil"
+)
+
+func (s *Service) Mutate(req *MutateRequest) (*iaas.PacketFilter, error) {
+	return s.MutateWithContext(context.Background(), req)
+}
+
+func (s *Service) MutateWithContext(ctx context.Context, req *MutateRequest) (*iaas.PacketFilter, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	client := iaas.NewPacketFilterOp(s.caller)
+	read := func(ctx context.Context) (*iaas.PacketFilter, error) {
+		return client.Read(ctx, req.Zone, req.ID)
+	}
+	update := func(ctx context.Context, current *iaas.PacketFilter) (*iaas.PacketFilter, error) {
+		params, err := (&UpdateRequest{Zone: req.Zone, ID: req.ID}).ToRequestParameter(current)
+		if err != nil {
+			return nil, err
+		}
+		return client.Update(ctx, req.Zone, req.ID, params, current.ExpressionHash)
+	}
+	return serviceutil.UpdateWithMutator(ctx, req.MaxRetries, read, req.Mutator, update)
+}
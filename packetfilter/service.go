@@ -14,7 +14,10 @@
 
 package packetfilter
 
-import "github.com/sacloud/iaas-api-go"
+import (
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
 
 // Service provides a high-level API of for PacketFilter
 type Service struct {
@@ -25,3 +28,12 @@ type Service struct {
 func New(caller iaas.APICaller) *Service {
 	return &Service{caller: caller}
 }
+
+var (
+	_ service.Creator[*CreateRequest, *iaas.PacketFilter]    = (*Service)(nil)
+	_ service.Reader[*ReadRequest, *iaas.PacketFilter]       = (*Service)(nil)
+	_ service.Updater[*UpdateRequest, *iaas.PacketFilter]    = (*Service)(nil)
+	_ service.Deleter[*DeleteRequest]                        = (*Service)(nil)
+	_ service.Finder[*FindRequest, *iaas.PacketFilter]       = (*Service)(nil)
+	_ service.FindIterator[*FindRequest, *iaas.PacketFilter] = (*Service)(nil)
+)
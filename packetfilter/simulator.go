@@ -0,0 +1,164 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetfilter
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// DefaultAction どのルールにもマッチしなかったパケットに対する動作
+//
+// パケットフィルタではどのルールにもマッチしなかったパケットは許可される
+var DefaultAction = types.Actions.Allow
+
+// Packet パケットフィルタの評価に用いる疑似パケット
+type Packet struct {
+	Protocol        types.Protocol // tcp/udp/icmp、その他のプロトコルの場合はip
+	SourceAddress   string
+	SourcePort      int
+	DestinationPort int
+	Fragment        bool // 先頭以外のフラグメントの場合true、ポートは評価されない
+}
+
+// Decision パケットフィルタの評価結果
+type Decision struct {
+	Action     types.Action
+	Index      int                          // 最初にマッチしたルールのインデックス、どのルールにもマッチしない場合は-1
+	Expression *iaas.PacketFilterExpression // 最初にマッチしたルール、どのルールにもマッチしない場合はnil
+}
+
+// Matched いずれかのルールにマッチしたか
+func (d *Decision) Matched() bool {
+	return d.Index >= 0
+}
+
+// TestCase Runで評価するテストケース
+type TestCase struct {
+	Name   string
+	Packet *Packet
+	Expect types.Action
+}
+
+// TestResult テストケースの評価結果
+type TestResult struct {
+	*TestCase
+
+	Decision *Decision
+	Passed   bool
+	Err      error // パケットが不正な場合のエラー
+}
+
+// EvaluateFilter パケットフィルタでpacketを評価する
+func EvaluateFilter(filter *iaas.PacketFilter, packet *Packet) (*Decision, error) {
+	return Evaluate(filter.Expression, packet)
+}
+
+// Evaluate ルールを上から順に評価し、最初にマッチしたルールでpacketの扱いを決定する
+func Evaluate(expressions []*iaas.PacketFilterExpression, packet *Packet) (*Decision, error) {
+	rules, err := parseExpressions(expressions)
+	if err != nil {
+		return nil, err
+	}
+	return evaluate(expressions, rules, packet)
+}
+
+// Run 各テストケースを評価し、期待した動作となったかを返す
+//
+// ルールが不正な場合はエラーを返す。パケットが不正な場合はTestResult.Errとして返す
+func Run(expressions []*iaas.PacketFilterExpression, cases []*TestCase) ([]*TestResult, error) {
+	rules, err := parseExpressions(expressions)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*TestResult
+	for _, tc := range cases {
+		result := &TestResult{TestCase: tc}
+		result.Decision, result.Err = evaluate(expressions, rules, tc.Packet)
+		result.Passed = result.Err == nil && result.Decision.Action == tc.Expect
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func evaluate(expressions []*iaas.PacketFilterExpression, rules []*rule, packet *Packet) (*Decision, error) {
+	p, err := parsePacket(packet)
+	if err != nil {
+		return nil, err
+	}
+	for i, r := range rules {
+		if r.match(p) {
+			return &Decision{Action: r.action, Index: i, Expression: expressions[i]}, nil
+		}
+	}
+	return &Decision{Action: DefaultAction, Index: -1}, nil
+}
+
+type parsedPacket struct {
+	*Packet
+	addr netip.Addr
+}
+
+func parsePacket(packet *Packet) (*parsedPacket, error) {
+	if packet == nil {
+		return nil, fmt.Errorf("packet is nil")
+	}
+	switch packet.Protocol {
+	case types.Protocols.TCP, types.Protocols.UDP, types.Protocols.ICMP, types.Protocols.IP:
+	default:
+		return nil, fmt.Errorf("invalid packet protocol: %q", packet.Protocol)
+	}
+	addr, err := netip.ParseAddr(packet.SourceAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid packet source address: %q", packet.SourceAddress)
+	}
+	if hasPort(packet.Protocol) && !packet.Fragment {
+		for _, port := range []int{packet.SourcePort, packet.DestinationPort} {
+			if port < 0 || port > 65535 {
+				return nil, fmt.Errorf("invalid packet port: %d", port)
+			}
+		}
+	}
+	return &parsedPacket{Packet: packet, addr: addr}, nil
+}
+
+func (r *rule) match(p *parsedPacket) bool {
+	switch r.protocol {
+	case types.Protocols.IP:
+	case types.Protocols.Fragment:
+		if !p.Fragment {
+			return false
+		}
+	default:
+		if p.Fragment || p.Protocol != r.protocol {
+			return false
+		}
+	}
+
+	if r.network != nil && !r.network.contains(p.addr) {
+		return false
+	}
+	if r.sourcePort != nil && !r.sourcePort.contains(p.SourcePort) {
+		return false
+	}
+	if r.destinationPort != nil && !r.destinationPort.contains(p.DestinationPort) {
+		return false
+	}
+	return true
+}
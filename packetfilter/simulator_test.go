@@ -0,0 +1,181 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetfilter
+
+import (
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+var testExpressions = []*iaas.PacketFilterExpression{
+	{
+		Protocol:        types.Protocols.TCP,
+		SourceNetwork:   "192.0.2.0/24",
+		DestinationPort: "22",
+		Action:          types.Actions.Allow,
+	},
+	{
+		Protocol:        types.Protocols.TCP,
+		DestinationPort: "22",
+		Action:          types.Actions.Deny,
+	},
+	{
+		Protocol:        types.Protocols.TCP,
+		SourcePort:      "1024-65535",
+		DestinationPort: "80",
+		Action:          types.Actions.Allow,
+	},
+	{
+		Protocol:      types.Protocols.UDP,
+		SourceNetwork: "198.51.100.0/255.255.255.128",
+		Action:        types.Actions.Allow,
+	},
+	{
+		Protocol: types.Protocols.ICMP,
+		Action:   types.Actions.Allow,
+	},
+	{
+		Protocol: types.Protocols.Fragment,
+		Action:   types.Actions.Allow,
+	},
+	{
+		Protocol: types.Protocols.IP,
+		Action:   types.Actions.Deny,
+	},
+}
+
+func TestEvaluate(t *testing.T) {
+	cases := []struct {
+		msg    string
+		packet *Packet
+		action types.Action
+		index  int
+	}{
+		{
+			msg:    "ssh from allowed network",
+			packet: &Packet{Protocol: types.Protocols.TCP, SourceAddress: "192.0.2.10", SourcePort: 50000, DestinationPort: 22},
+			action: types.Actions.Allow,
+			index:  0,
+		},
+		{
+			msg:    "ssh from other network",
+			packet: &Packet{Protocol: types.Protocols.TCP, SourceAddress: "203.0.113.10", SourcePort: 50000, DestinationPort: 22},
+			action: types.Actions.Deny,
+			index:  1,
+		},
+		{
+			msg:    "http from ephemeral port",
+			packet: &Packet{Protocol: types.Protocols.TCP, SourceAddress: "203.0.113.10", SourcePort: 50000, DestinationPort: 80},
+			action: types.Actions.Allow,
+			index:  2,
+		},
+		{
+			msg:    "http from well-known port",
+			packet: &Packet{Protocol: types.Protocols.TCP, SourceAddress: "203.0.113.10", SourcePort: 53, DestinationPort: 80},
+			action: types.Actions.Deny,
+			index:  6,
+		},
+		{
+			msg:    "udp from netmask network",
+			packet: &Packet{Protocol: types.Protocols.UDP, SourceAddress: "198.51.100.127", SourcePort: 53, DestinationPort: 53},
+			action: types.Actions.Allow,
+			index:  3,
+		},
+		{
+			msg:    "udp from out of netmask network",
+			packet: &Packet{Protocol: types.Protocols.UDP, SourceAddress: "198.51.100.128", SourcePort: 53, DestinationPort: 53},
+			action: types.Actions.Deny,
+			index:  6,
+		},
+		{
+			msg:    "icmp",
+			packet: &Packet{Protocol: types.Protocols.ICMP, SourceAddress: "203.0.113.10"},
+			action: types.Actions.Allow,
+			index:  4,
+		},
+		{
+			msg:    "fragment",
+			packet: &Packet{Protocol: types.Protocols.TCP, SourceAddress: "203.0.113.10", Fragment: true},
+			action: types.Actions.Allow,
+			index:  5,
+		},
+	}
+
+	for _, tc := range cases {
+		decision, err := Evaluate(testExpressions, tc.packet)
+		require.NoError(t, err, tc.msg)
+		require.Equal(t, tc.action, decision.Action, tc.msg)
+		require.Equal(t, tc.index, decision.Index, tc.msg)
+		require.Equal(t, testExpressions[tc.index], decision.Expression, tc.msg)
+	}
+}
+
+func TestEvaluate_default(t *testing.T) {
+	decision, err := EvaluateFilter(&iaas.PacketFilter{Expression: testExpressions[:1]}, &Packet{
+		Protocol:        types.Protocols.TCP,
+		SourceAddress:   "203.0.113.10",
+		DestinationPort: 22,
+	})
+	require.NoError(t, err)
+	require.False(t, decision.Matched())
+	require.Equal(t, DefaultAction, decision.Action)
+	require.Nil(t, decision.Expression)
+}
+
+func TestEvaluate_errors(t *testing.T) {
+	_, err := Evaluate([]*iaas.PacketFilterExpression{
+		{Protocol: types.Protocols.TCP, SourceNetwork: "192.0.2.0/33", Action: types.Actions.Allow},
+	}, &Packet{Protocol: types.Protocols.TCP, SourceAddress: "192.0.2.1"})
+	require.EqualError(t, err, `expression[0]: invalid source network: "192.0.2.0/33"`)
+
+	_, err = Evaluate([]*iaas.PacketFilterExpression{
+		{Protocol: types.Protocols.TCP, DestinationPort: "80-22", Action: types.Actions.Allow},
+	}, &Packet{Protocol: types.Protocols.TCP, SourceAddress: "192.0.2.1"})
+	require.EqualError(t, err, `expression[0]: invalid port: "80-22"`)
+
+	_, err = Evaluate(testExpressions, &Packet{Protocol: types.Protocols.TCP, SourceAddress: "invalid"})
+	require.EqualError(t, err, `invalid packet source address: "invalid"`)
+}
+
+func TestRun(t *testing.T) {
+	results, err := Run(testExpressions, []*TestCase{
+		{
+			Name:   "ssh from office",
+			Packet: &Packet{Protocol: types.Protocols.TCP, SourceAddress: "192.0.2.10", SourcePort: 50000, DestinationPort: 22},
+			Expect: types.Actions.Allow,
+		},
+		{
+			Name:   "ssh from internet",
+			Packet: &Packet{Protocol: types.Protocols.TCP, SourceAddress: "203.0.113.10", SourcePort: 50000, DestinationPort: 22},
+			Expect: types.Actions.Allow,
+		},
+		{
+			Name:   "invalid packet",
+			Packet: &Packet{Protocol: types.Protocols.Fragment, SourceAddress: "203.0.113.10"},
+			Expect: types.Actions.Allow,
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	require.True(t, results[0].Passed)
+	require.False(t, results[1].Passed)
+	require.Equal(t, 1, results[1].Decision.Index)
+	require.False(t, results[2].Passed)
+	require.Error(t, results[2].Err)
+}
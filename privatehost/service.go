@@ -14,7 +14,10 @@
 
 package privatehost
 
-import "github.com/sacloud/iaas-api-go"
+import (
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
 
 // Service provides a high-level API of for PrivateHost
 type Service struct {
@@ -25,3 +28,12 @@ type Service struct {
 func New(caller iaas.APICaller) *Service {
 	return &Service{caller: caller}
 }
+
+var (
+	_ service.Creator[*CreateRequest, *iaas.PrivateHost]    = (*Service)(nil)
+	_ service.Reader[*ReadRequest, *iaas.PrivateHost]       = (*Service)(nil)
+	_ service.Updater[*UpdateRequest, *iaas.PrivateHost]    = (*Service)(nil)
+	_ service.Deleter[*DeleteRequest]                       = (*Service)(nil)
+	_ service.Finder[*FindRequest, *iaas.PrivateHost]       = (*Service)(nil)
+	_ service.FindIterator[*FindRequest, *iaas.PrivateHost] = (*Service)(nil)
+)
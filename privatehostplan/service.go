@@ -14,7 +14,10 @@
 
 package privatehostplan
 
-import "github.com/sacloud/iaas-api-go"
+import (
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
 
 // Service provides a high-level API of for PrivateHostPlan
 type Service struct {
@@ -25,3 +28,9 @@ type Service struct {
 func New(caller iaas.APICaller) *Service {
 	return &Service{caller: caller}
 }
+
+var (
+	_ service.Reader[*ReadRequest, *iaas.PrivateHostPlan]       = (*Service)(nil)
+	_ service.Finder[*FindRequest, *iaas.PrivateHostPlan]       = (*Service)(nil)
+	_ service.FindIterator[*FindRequest, *iaas.PrivateHostPlan] = (*Service)(nil)
+)
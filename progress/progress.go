@@ -0,0 +1,179 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package progress
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/accessor"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// EventType イベント種別
+type EventType string
+
+const (
+	// EventCreated リソースが作成された
+	EventCreated = EventType("created")
+	// EventCopying ディスク/アーカイブのコピーなど、リソースが利用可能になるのを待っている
+	EventCopying = EventType("copying")
+	// EventUploading ファイルをアップロードしている
+	EventUploading = EventType("uploading")
+	// EventReady リソースが利用可能になった
+	EventReady = EventType("ready")
+	// EventRetrying リソースの再作成などのリトライを行う
+	EventRetrying = EventType("retrying")
+	// EventBooting リソースを起動している
+	EventBooting = EventType("booting")
+	// EventDeleting リソースを削除している
+	EventDeleting = EventType("deleting")
+)
+
+// Event 進捗イベント
+type Event struct {
+	Type     EventType
+	Resource string // リソース種別(例: Server, Disk, Archive)
+	Zone     string
+	ID       types.ID
+	Percent  int    // EventCopyingの場合のコピー進捗率(0〜100)、不明な場合は-1
+	Attempt  int    // EventRetryingの場合の試行回数(1始まり)
+	Message  string // 補足情報
+	Err      error  // EventRetryingの場合のリトライの原因となったエラー
+	Time     time.Time
+}
+
+// String ログ出力向けの文字列表現
+func (e *Event) String() string {
+	s := fmt.Sprintf("%s[%s]: %s", e.Resource, e.ID, e.Type)
+	switch {
+	case e.Type == EventCopying && e.Percent >= 0:
+		s += fmt.Sprintf(" %d%%", e.Percent)
+	case e.Type == EventRetrying && e.Attempt > 0:
+		s += fmt.Sprintf(" (attempt %d)", e.Attempt)
+	}
+	if e.Message != "" {
+		s += ": " + e.Message
+	}
+	if e.Err != nil {
+		s += ": " + e.Err.Error()
+	}
+	return s
+}
+
+// Observer イベントの通知先
+//
+// Observeは処理中のgoroutineから同期的に呼ばれる。
+// 複数のリソースを並列に構築する場合は複数のgoroutineから呼ばれるため、実装はgoroutine safeである必要がある。
+type Observer interface {
+	Observe(event *Event)
+}
+
+// ObserverFunc 関数をObserverとして扱うためのアダプタ
+type ObserverFunc func(event *Event)
+
+// Observe Observerの実装
+func (f ObserverFunc) Observe(event *Event) {
+	f(event)
+}
+
+type observerKey struct{}
+
+// WithObserver Observerを設定したcontext.Contextを返す
+//
+// 戻り値のcontext.Contextを各builderやsetup.RetryableSetupに渡すと、処理中に発生したイベントがObserverへ通知される。
+func WithObserver(ctx context.Context, observer Observer) context.Context {
+	return context.WithValue(ctx, observerKey{}, observer)
+}
+
+// FromContext ctxに設定されたObserverを返す、設定されていない場合はnil
+func FromContext(ctx context.Context) Observer {
+	if observer, ok := ctx.Value(observerKey{}).(Observer); ok {
+		return observer
+	}
+	return nil
+}
+
+// Emit ctxに設定されたObserverへイベントを通知する
+func Emit(ctx context.Context, event *Event) {
+	observer := FromContext(ctx)
+	if observer == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	observer.Observe(event)
+}
+
+// WaitForState waiterでの待機を行い、ポーリングで取得した状態をEventCopyingとして通知する
+//
+// 待機完了時にはEventReadyを通知する。ctxにObserverが設定されていない場合はwaiter.WaitForStateと同じ動作となる。
+func WaitForState(ctx context.Context, waiter iaas.StateWaiter, resource, zone string, id types.ID) (interface{}, error) {
+	if FromContext(ctx) == nil {
+		return waiter.WaitForState(ctx)
+	}
+
+	compChan, progressChan, errChan := waiter.WaitForStateAsync(ctx)
+	var state interface{}
+	for {
+		select {
+		case v := <-compChan:
+			Emit(ctx, &Event{Type: EventReady, Resource: resource, Zone: zone, ID: id, Percent: 100})
+			return v, nil
+		case v := <-progressChan:
+			state = v
+			Emit(ctx, &Event{Type: EventCopying, Resource: resource, Zone: zone, ID: id, Percent: CopyPercent(v)})
+		case err := <-errChan:
+			return state, err
+		}
+	}
+}
+
+// migrationState コピー中のリソースの状態を参照するためのインターフェース
+type migrationState interface {
+	GetMigratedMB() int
+	GetSizeMB() int
+}
+
+// CopyPercent リソースの状態からコピー進捗率(0〜100)を算出する、算出できない場合は-1
+func CopyPercent(state interface{}) int {
+	if a, ok := state.(accessor.Availability); ok && a != nil && a.GetAvailability().IsAvailable() {
+		return 100
+	}
+	if m, ok := state.(migrationState); ok && m != nil && m.GetSizeMB() > 0 {
+		percent := m.GetMigratedMB() * 100 / m.GetSizeMB()
+		if percent > 100 {
+			percent = 100
+		}
+		return percent
+	}
+	return -1
+}
+
+// ResourceName リソースの型名を返す(例: *iaas.Disk の場合はDisk)
+func ResourceName(v interface{}) string {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return ""
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
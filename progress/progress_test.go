@@ -0,0 +1,88 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package progress
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+type dummyState struct {
+	availability types.EAvailability
+	migratedMB   int
+	sizeMB       int
+}
+
+func (d *dummyState) GetAvailability() types.EAvailability  { return d.availability }
+func (d *dummyState) SetAvailability(v types.EAvailability) { d.availability = v }
+func (d *dummyState) GetMigratedMB() int                    { return d.migratedMB }
+func (d *dummyState) GetSizeMB() int                        { return d.sizeMB }
+
+func TestEmit(t *testing.T) {
+	// Observerが設定されていない場合は何もしない
+	Emit(context.Background(), &Event{Type: EventCreated})
+
+	var events []*Event
+	ctx := WithObserver(context.Background(), ObserverFunc(func(event *Event) {
+		events = append(events, event)
+	}))
+	Emit(ctx, &Event{Type: EventCreated, Resource: "Disk", ID: 1})
+	Emit(ctx, &Event{Type: EventCopying, Resource: "Disk", ID: 1, Percent: 40})
+
+	require.Len(t, events, 2)
+	require.Equal(t, EventCreated, events[0].Type)
+	require.False(t, events[0].Time.IsZero())
+	require.Equal(t, "Disk[1]: copying 40%", events[1].String())
+}
+
+func TestEvent_String(t *testing.T) {
+	cases := []struct {
+		in     *Event
+		expect string
+	}{
+		{
+			in:     &Event{Type: EventCreated, Resource: "Server", ID: 1},
+			expect: "Server[1]: created",
+		},
+		{
+			in:     &Event{Type: EventCopying, Resource: "Disk", ID: 1, Percent: -1},
+			expect: "Disk[1]: copying",
+		},
+		{
+			in:     &Event{Type: EventRetrying, Resource: "Database", ID: 1, Attempt: 2, Err: errors.New("dummy")},
+			expect: "Database[1]: retrying (attempt 2): dummy",
+		},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.expect, tc.in.String())
+	}
+}
+
+func TestCopyPercent(t *testing.T) {
+	require.Equal(t, -1, CopyPercent(nil))
+	require.Equal(t, -1, CopyPercent(&dummyState{availability: types.Availabilities.Migrating}))
+	require.Equal(t, 40, CopyPercent(&dummyState{availability: types.Availabilities.Migrating, migratedMB: 8192, sizeMB: 20480}))
+	require.Equal(t, 100, CopyPercent(&dummyState{availability: types.Availabilities.Available}))
+}
+
+func TestResourceName(t *testing.T) {
+	require.Equal(t, "", ResourceName(nil))
+	require.Equal(t, "dummyState", ResourceName(&dummyState{}))
+	require.Equal(t, "dummyState", ResourceName((*dummyState)(nil)))
+}
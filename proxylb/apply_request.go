@@ -0,0 +1,78 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxylb
+
+import (
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/proxylb/builder"
+	"github.com/sacloud/iaas-service-go/serviceutil"
+	"github.com/sacloud/packages-go/validate"
+)
+
+// ApplyRequest ProxyLBの作成/更新パラメータ
+//
+// IDが指定された場合は既存のProxyLBを更新し、指定されない場合は作成する。
+// PrimaryCert/AdditionalCertsはサーバ証明書と中間証明書に差分がある場合のみ更新される。
+// Exportで出力したApplyRequestは、IncludePrivateKeysを指定しない限り秘密鍵が空となる。
+// また、Let's Encryptが有効な場合は証明書を含まない
+type ApplyRequest struct {
+	ID types.ID `service:"-"`
+
+	Name                 string `validate:"required"`
+	Description          string `validate:"min=0,max=512"`
+	Tags                 types.Tags
+	IconID               types.ID
+	Plan                 types.EProxyLBPlan `validate:"required,oneof=100 500 1000 5000 10000 50000 100000 400000"`
+	HealthCheck          *iaas.ProxyLBHealthCheck
+	SorryServer          *iaas.ProxyLBSorryServer
+	BindPorts            []*iaas.ProxyLBBindPort
+	Servers              []*iaas.ProxyLBServer
+	Rules                []*iaas.ProxyLBRule
+	LetsEncrypt          *iaas.ProxyLBACMESetting
+	StickySession        *iaas.ProxyLBStickySession
+	Gzip                 *iaas.ProxyLBGzip
+	BackendHttpKeepAlive *iaas.ProxyLBBackendHttpKeepAlive
+	ProxyProtocol        *iaas.ProxyLBProxyProtocol
+	Syslog               *iaas.ProxyLBSyslog
+	Timeout              *iaas.ProxyLBTimeout
+	UseVIPFailover       bool
+	Region               types.EProxyLBRegion
+	MonitoringSuiteLog   *iaas.MonitoringSuiteLog
+	OriginGuard          *iaas.ProxyLBOriginGuard
+	StrictRule           *iaas.ProxyLBStrictRule
+
+	PrimaryCert     *iaas.ProxyLBPrimaryCert      `service:"-"`
+	AdditionalCerts []*iaas.ProxyLBAdditionalCert `service:"-"`
+
+	SettingsHash string // for update
+}
+
+func (req *ApplyRequest) Validate() error {
+	return validate.New().Struct(req)
+}
+
+func (req *ApplyRequest) Builder(caller iaas.APICaller) (*builder.Builder, error) {
+	b := &builder.Builder{
+		ID:              req.ID,
+		PrimaryCert:     req.PrimaryCert,
+		AdditionalCerts: req.AdditionalCerts,
+		Caller:          caller,
+	}
+	if err := serviceutil.RequestConvertTo(req, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
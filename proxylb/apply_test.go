@@ -0,0 +1,115 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxylb
+
+import (
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/testutil"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/proxylb/builder"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxyLBService_convertApplyRequest(t *testing.T) {
+	caller := testutil.SingletonAPICaller()
+	name := testutil.ResourceName("proxylb-service")
+
+	cases := []struct {
+		in     *ApplyRequest
+		expect *builder.Builder
+	}{
+		{
+			in: &ApplyRequest{
+				ID:          101,
+				Name:        name,
+				Description: "desc",
+				Tags:        types.Tags{"tag1", "tag2"},
+				Plan:        types.ProxyLBPlans.CPS100,
+				HealthCheck: &iaas.ProxyLBHealthCheck{
+					Protocol:  types.ProxyLBProtocols.HTTP,
+					Path:      "/",
+					DelayLoop: 10,
+				},
+				BindPorts: []*iaas.ProxyLBBindPort{
+					{
+						ProxyMode: types.ProxyLBProxyModes.HTTP,
+						Port:      80,
+					},
+				},
+				Servers: []*iaas.ProxyLBServer{
+					{
+						IPAddress: "192.0.2.11",
+						Port:      80,
+						Enabled:   true,
+					},
+				},
+				LetsEncrypt: &iaas.ProxyLBACMESetting{
+					CommonName: "www.example.com",
+					Enabled:    true,
+				},
+				Region: types.ProxyLBRegions.IS1,
+				PrimaryCert: &iaas.ProxyLBPrimaryCert{
+					ServerCertificate: "server-cert",
+					PrivateKey:        "private-key",
+				},
+				SettingsHash: "hash",
+			},
+			expect: &builder.Builder{
+				ID:          101,
+				Name:        name,
+				Description: "desc",
+				Tags:        types.Tags{"tag1", "tag2"},
+				Plan:        types.ProxyLBPlans.CPS100,
+				HealthCheck: &iaas.ProxyLBHealthCheck{
+					Protocol:  types.ProxyLBProtocols.HTTP,
+					Path:      "/",
+					DelayLoop: 10,
+				},
+				BindPorts: []*iaas.ProxyLBBindPort{
+					{
+						ProxyMode: types.ProxyLBProxyModes.HTTP,
+						Port:      80,
+					},
+				},
+				Servers: []*iaas.ProxyLBServer{
+					{
+						IPAddress: "192.0.2.11",
+						Port:      80,
+						Enabled:   true,
+					},
+				},
+				LetsEncrypt: &iaas.ProxyLBACMESetting{
+					CommonName: "www.example.com",
+					Enabled:    true,
+				},
+				Region: types.ProxyLBRegions.IS1,
+				PrimaryCert: &iaas.ProxyLBPrimaryCert{
+					ServerCertificate: "server-cert",
+					PrivateKey:        "private-key",
+				},
+				SettingsHash: "hash",
+				Caller:       caller,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		builder, err := tc.in.Builder(caller)
+		require.NoError(t, err)
+		require.EqualValues(t, tc.expect, builder)
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/helper/plans"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// Builder エンハンスドロードバランサの構築を行う
+//
+// 更新時はバインドポート/ルール/実サーバ/Let's Encrypt設定を含む全ての設定を指定された値で置き換え、
+// 証明書に差分がある場合は証明書も置き換える
+type Builder struct {
+	ID types.ID
+
+	Name                 string
+	Description          string
+	Tags                 types.Tags
+	IconID               types.ID
+	Plan                 types.EProxyLBPlan
+	HealthCheck          *iaas.ProxyLBHealthCheck
+	SorryServer          *iaas.ProxyLBSorryServer
+	BindPorts            []*iaas.ProxyLBBindPort
+	Servers              []*iaas.ProxyLBServer
+	Rules                []*iaas.ProxyLBRule
+	LetsEncrypt          *iaas.ProxyLBACMESetting
+	StickySession        *iaas.ProxyLBStickySession
+	Gzip                 *iaas.ProxyLBGzip
+	BackendHttpKeepAlive *iaas.ProxyLBBackendHttpKeepAlive
+	ProxyProtocol        *iaas.ProxyLBProxyProtocol
+	Syslog               *iaas.ProxyLBSyslog
+	Timeout              *iaas.ProxyLBTimeout
+	UseVIPFailover       bool
+	Region               types.EProxyLBRegion
+	MonitoringSuiteLog   *iaas.MonitoringSuiteLog
+	OriginGuard          *iaas.ProxyLBOriginGuard
+	StrictRule           *iaas.ProxyLBStrictRule
+
+	PrimaryCert     *iaas.ProxyLBPrimaryCert
+	AdditionalCerts []*iaas.ProxyLBAdditionalCert
+
+	SettingsHash string // for update
+	Caller       iaas.APICaller
+}
+
+func (b *Builder) Build(ctx context.Context) (*iaas.ProxyLB, error) {
+	if b.ID.IsEmpty() {
+		return b.create(ctx)
+	}
+	return b.update(ctx)
+}
+
+func (b *Builder) create(ctx context.Context) (*iaas.ProxyLB, error) {
+	client := iaas.NewProxyLBOp(b.Caller)
+	created, err := client.Create(ctx, &iaas.ProxyLBCreateRequest{
+		Plan:                 b.Plan,
+		HealthCheck:          b.HealthCheck,
+		SorryServer:          b.SorryServer,
+		BindPorts:            b.BindPorts,
+		Servers:              b.Servers,
+		Rules:                b.Rules,
+		LetsEncrypt:          b.LetsEncrypt,
+		StickySession:        b.StickySession,
+		Timeout:              b.Timeout,
+		Gzip:                 b.Gzip,
+		BackendHttpKeepAlive: b.BackendHttpKeepAlive,
+		ProxyProtocol:        b.ProxyProtocol,
+		Syslog:               b.Syslog,
+		UseVIPFailover:       b.UseVIPFailover,
+		Region:               b.Region,
+		Name:                 b.Name,
+		Description:          b.Description,
+		Tags:                 b.Tags,
+		IconID:               b.IconID,
+		MonitoringSuiteLog:   b.MonitoringSuiteLog,
+		OriginGuard:          b.OriginGuard,
+		StrictRule:           b.StrictRule,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if b.hasCertificates() {
+		if err := b.setCertificates(ctx, created.ID); err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
+
+func (b *Builder) validateForUpdate(current *iaas.ProxyLB) error {
+	if current.UseVIPFailover != b.UseVIPFailover {
+		return errors.New("UseVIPFailover cannot be changed")
+	}
+	if b.Region != "" && current.Region != b.Region {
+		return errors.New("Region cannot be changed")
+	}
+	return nil
+}
+
+func (b *Builder) update(ctx context.Context) (*iaas.ProxyLB, error) {
+	client := iaas.NewProxyLBOp(b.Caller)
+	current, err := client.Read(ctx, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.validateForUpdate(current); err != nil {
+		return nil, err
+	}
+
+	updated, err := client.Update(ctx, b.ID, &iaas.ProxyLBUpdateRequest{
+		HealthCheck:          b.HealthCheck,
+		SorryServer:          b.SorryServer,
+		BindPorts:            b.BindPorts,
+		Servers:              b.Servers,
+		Rules:                b.Rules,
+		LetsEncrypt:          b.LetsEncrypt,
+		StickySession:        b.StickySession,
+		Timeout:              b.Timeout,
+		Gzip:                 b.Gzip,
+		BackendHttpKeepAlive: b.BackendHttpKeepAlive,
+		ProxyProtocol:        b.ProxyProtocol,
+		Syslog:               b.Syslog,
+		Name:                 b.Name,
+		Description:          b.Description,
+		Tags:                 b.Tags,
+		IconID:               b.IconID,
+		MonitoringSuiteLog:   b.MonitoringSuiteLog,
+		OriginGuard:          b.OriginGuard,
+		StrictRule:           b.StrictRule,
+		SettingsHash:         b.SettingsHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if b.Plan != 0 && updated.Plan != b.Plan {
+		updated, err = plans.ChangeProxyLBPlan(ctx, b.Caller, updated.ID, b.Plan.Int())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := b.reconcileCertificates(ctx, updated.ID); err != nil {
+		return updated, err
+	}
+	return updated, nil
+}
+
+func (b *Builder) hasCertificates() bool {
+	return b.PrimaryCert != nil || len(b.AdditionalCerts) > 0
+}
+
+func (b *Builder) setCertificates(ctx context.Context, id types.ID) error {
+	_, err := iaas.NewProxyLBOp(b.Caller).SetCertificates(ctx, id, &iaas.ProxyLBSetCertificatesRequest{
+		PrimaryCerts:    b.PrimaryCert,
+		AdditionalCerts: b.AdditionalCerts,
+	})
+	return err
+}
+
+// reconcileCertificates 証明書に差分がある場合のみ証明書を置き換える
+//
+// 証明書が指定されておらずLet's Encryptが有効な場合は、Let's Encryptにより発行された証明書を維持する
+func (b *Builder) reconcileCertificates(ctx context.Context, id types.ID) error {
+	client := iaas.NewProxyLBOp(b.Caller)
+	current, err := client.GetCertificates(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !b.hasCertificates() {
+		if b.LetsEncrypt != nil && b.LetsEncrypt.Enabled {
+			return nil
+		}
+		if current == nil || (current.PrimaryCert == nil && len(current.AdditionalCerts) == 0) {
+			return nil
+		}
+		return client.DeleteCertificates(ctx, id)
+	}
+
+	if !certificatesChanged(current, b.PrimaryCert, b.AdditionalCerts) {
+		return nil
+	}
+	return b.setCertificates(ctx, id)
+}
+
+// certificatesChanged 証明書に差分があるか判定する
+//
+// 秘密鍵はAPIから参照できない場合があるため、サーバ証明書と中間証明書のみを比較する
+func certificatesChanged(current *iaas.ProxyLBCertificates, primary *iaas.ProxyLBPrimaryCert, additional []*iaas.ProxyLBAdditionalCert) bool {
+	var currentPrimary *iaas.ProxyLBPrimaryCert
+	var currentAdditional []*iaas.ProxyLBAdditionalCert
+	if current != nil {
+		currentPrimary = current.PrimaryCert
+		currentAdditional = current.AdditionalCerts
+	}
+
+	if (currentPrimary == nil) != (primary == nil) {
+		return true
+	}
+	if primary != nil && !samePEM(currentPrimary.ServerCertificate, primary.ServerCertificate, currentPrimary.IntermediateCertificate, primary.IntermediateCertificate) {
+		return true
+	}
+
+	if len(currentAdditional) != len(additional) {
+		return true
+	}
+	for i := range additional {
+		c, d := currentAdditional[i], additional[i]
+		if !samePEM(c.ServerCertificate, d.ServerCertificate, c.IntermediateCertificate, d.IntermediateCertificate) {
+			return true
+		}
+	}
+	return false
+}
+
+// samePEM 前後の空白を無視してサーバ証明書と中間証明書がそれぞれ一致するか判定する
+func samePEM(currentServer, desiredServer, currentIntermediate, desiredIntermediate string) bool {
+	return strings.TrimSpace(currentServer) == strings.TrimSpace(desiredServer) &&
+		strings.TrimSpace(currentIntermediate) == strings.TrimSpace(desiredIntermediate)
+}
@@ -0,0 +1,87 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder_certificatesChanged(t *testing.T) {
+	current := &iaas.ProxyLBCertificates{
+		PrimaryCert: &iaas.ProxyLBPrimaryCert{
+			ServerCertificate:       "server\n",
+			IntermediateCertificate: "intermediate\n",
+		},
+		AdditionalCerts: []*iaas.ProxyLBAdditionalCert{
+			{ServerCertificate: "additional"},
+		},
+	}
+
+	cases := []struct {
+		msg        string
+		current    *iaas.ProxyLBCertificates
+		primary    *iaas.ProxyLBPrimaryCert
+		additional []*iaas.ProxyLBAdditionalCert
+		expect     bool
+	}{
+		{
+			msg:     "same",
+			current: current,
+			primary: &iaas.ProxyLBPrimaryCert{
+				ServerCertificate:       "server",
+				IntermediateCertificate: "intermediate",
+				PrivateKey:              "key",
+			},
+			additional: []*iaas.ProxyLBAdditionalCert{
+				{ServerCertificate: "additional", PrivateKey: "key"},
+			},
+			expect: false,
+		},
+		{
+			msg:     "primary changed",
+			current: current,
+			primary: &iaas.ProxyLBPrimaryCert{
+				ServerCertificate:       "server-upd",
+				IntermediateCertificate: "intermediate",
+			},
+			additional: []*iaas.ProxyLBAdditionalCert{
+				{ServerCertificate: "additional"},
+			},
+			expect: true,
+		},
+		{
+			msg:     "additional removed",
+			current: current,
+			primary: &iaas.ProxyLBPrimaryCert{
+				ServerCertificate:       "server",
+				IntermediateCertificate: "intermediate",
+			},
+			expect: true,
+		},
+		{
+			msg:     "no current certificates",
+			current: nil,
+			primary: &iaas.ProxyLBPrimaryCert{ServerCertificate: "server"},
+			expect:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		require.Equal(t, tc.expect, certificatesChanged(tc.current, tc.primary, tc.additional), tc.msg)
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxylb
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+// Certificate PEMから読み込んだ証明書と秘密鍵
+type Certificate struct {
+	Leaf          *x509.Certificate   // サーバ証明書
+	Intermediates []*x509.Certificate // サーバ証明書から順に並べた中間証明書
+	PrivateKey    crypto.PrivateKey
+
+	privateKeyPEM []byte
+}
+
+// LoadCertificateFiles 証明書チェーンと秘密鍵をPEMファイルから読み込む
+func LoadCertificateFiles(chainFile, keyFile string) (*Certificate, error) {
+	chainPEM, err := os.ReadFile(chainFile)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCertificatePEM(chainPEM, keyPEM)
+}
+
+// LoadCertificate 証明書チェーンと秘密鍵をPEM形式で読み込む
+func LoadCertificate(chain, key io.Reader) (*Certificate, error) {
+	chainPEM, err := io.ReadAll(chain)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := io.ReadAll(key)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCertificatePEM(chainPEM, keyPEM)
+}
+
+// ParseCertificatePEM PEM形式の証明書チェーンと秘密鍵をパースする
+//
+// 証明書チェーンからは秘密鍵に対応するサーバ証明書を探し、残りの証明書から中間証明書のチェーンを組み立てる。
+// 自己署名されたルート証明書やチェーンに含まれない証明書は無視する。
+func ParseCertificatePEM(chainPEM, keyPEM []byte) (*Certificate, error) {
+	certs, err := parseCertificates(chainPEM)
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no certificate found in PEM data")
+	}
+
+	key, keyBlock, err := parsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type: %T", key)
+	}
+	pub, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type: %T", signer.Public())
+	}
+
+	var leaf *x509.Certificate
+	for _, cert := range certs {
+		if pub.Equal(cert.PublicKey) {
+			leaf = cert
+			break
+		}
+	}
+	if leaf == nil {
+		return nil, errors.New("private key does not match any certificate")
+	}
+
+	return &Certificate{
+		Leaf:          leaf,
+		Intermediates: buildChain(leaf, certs),
+		PrivateKey:    key,
+		privateKeyPEM: pem.EncodeToMemory(keyBlock),
+	}, nil
+}
+
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs, nil
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing certificate failed: %s", err)
+		}
+		certs = append(certs, cert)
+	}
+}
+
+func parsePrivateKey(data []byte) (crypto.PrivateKey, *pem.Block, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, nil, errors.New("no private key found in PEM data")
+		}
+
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+			return key, block, err
+		case "EC PRIVATE KEY":
+			key, err := x509.ParseECPrivateKey(block.Bytes)
+			return key, block, err
+		case "PRIVATE KEY":
+			key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, nil, err
+			}
+			switch key.(type) {
+			case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
+				return key, block, nil
+			}
+			return nil, nil, fmt.Errorf("unsupported private key type: %T", key)
+		}
+	}
+}
+
+// buildChain leafから発行者をたどり中間証明書のチェーンを組み立てる
+func buildChain(leaf *x509.Certificate, certs []*x509.Certificate) []*x509.Certificate {
+	var chain []*x509.Certificate
+	current := leaf
+	for {
+		var issuer *x509.Certificate
+		for _, cert := range certs {
+			if cert.Equal(current) || isSelfSigned(cert) {
+				continue
+			}
+			if bytes.Equal(cert.RawSubject, current.RawIssuer) && current.CheckSignatureFrom(cert) == nil {
+				issuer = cert
+				break
+			}
+		}
+		if issuer == nil || containsCertificate(chain, issuer) {
+			return chain
+		}
+		chain = append(chain, issuer)
+		current = issuer
+	}
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawSubject, cert.RawIssuer) && cert.CheckSignatureFrom(cert) == nil
+}
+
+func containsCertificate(certs []*x509.Certificate, cert *x509.Certificate) bool {
+	for _, c := range certs {
+		if c.Equal(cert) {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify サーバ証明書と中間証明書がnow時点で有効期間内か検証する
+func (c *Certificate) Verify(now time.Time) error {
+	for _, cert := range append([]*x509.Certificate{c.Leaf}, c.Intermediates...) {
+		if now.Before(cert.NotBefore) {
+			return fmt.Errorf("certificate %q is not yet valid: valid from %s", cert.Subject.CommonName, cert.NotBefore.Format(time.RFC3339))
+		}
+		if now.After(cert.NotAfter) {
+			return fmt.Errorf("certificate %q has expired: valid until %s", cert.Subject.CommonName, cert.NotAfter.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// ServerCertificatePEM サーバ証明書をPEM形式で返す
+func (c *Certificate) ServerCertificatePEM() string {
+	return encodeCertificates(c.Leaf)
+}
+
+// IntermediateCertificatePEM 中間証明書のチェーンをPEM形式で返す
+func (c *Certificate) IntermediateCertificatePEM() string {
+	return encodeCertificates(c.Intermediates...)
+}
+
+// PrivateKeyPEM 秘密鍵をPEM形式で返す
+func (c *Certificate) PrivateKeyPEM() string {
+	return string(c.privateKeyPEM)
+}
+
+// PrimaryCert iaas.ProxyLBPrimaryCertへ変換する
+func (c *Certificate) PrimaryCert() *iaas.ProxyLBPrimaryCert {
+	return &iaas.ProxyLBPrimaryCert{
+		ServerCertificate:       c.ServerCertificatePEM(),
+		IntermediateCertificate: c.IntermediateCertificatePEM(),
+		PrivateKey:              c.PrivateKeyPEM(),
+	}
+}
+
+// AdditionalCert iaas.ProxyLBAdditionalCertへ変換する
+func (c *Certificate) AdditionalCert() *iaas.ProxyLBAdditionalCert {
+	return &iaas.ProxyLBAdditionalCert{
+		ServerCertificate:       c.ServerCertificatePEM(),
+		IntermediateCertificate: c.IntermediateCertificatePEM(),
+		PrivateKey:              c.PrivateKeyPEM(),
+	}
+}
+
+func encodeCertificates(certs ...*x509.Certificate) string {
+	var sb strings.Builder
+	for _, cert := range certs {
+		sb.Write(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+	}
+	return sb.String()
+}
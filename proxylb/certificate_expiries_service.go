@@ -0,0 +1,144 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxylb
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// CertificateExpiry ProxyLBに設定されている証明書の有効期限
+type CertificateExpiry struct {
+	ProxyLBID   types.ID
+	ProxyLBName string
+	Primary     bool // プライマリ証明書の場合true、追加証明書の場合false
+	CommonName  string
+	AltNames    []string
+	NotAfter    time.Time
+}
+
+// ExpiresWithin now時点からdの期間内に有効期限を迎えるか(有効期限切れを含む)
+func (e *CertificateExpiry) ExpiresWithin(now time.Time, d time.Duration) bool {
+	return !e.NotAfter.After(now.Add(d))
+}
+
+func (s *Service) CertificateExpiries(req *CertificateExpiriesRequest) ([]*CertificateExpiry, error) {
+	return s.CertificateExpiriesWithContext(context.Background(), req)
+}
+
+// CertificateExpiriesWithContext 各ProxyLBに設定されている証明書の有効期限を返す
+func (s *Service) CertificateExpiriesWithContext(ctx context.Context, req *CertificateExpiriesRequest) ([]*CertificateExpiry, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	targets, err := s.proxyLBs(ctx, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	client := iaas.NewProxyLBOp(s.caller)
+	var results []*CertificateExpiry
+	for _, proxyLB := range targets {
+		certs, err := client.GetCertificates(ctx, proxyLB.ID)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, certificateExpiries(proxyLB, certs)...)
+	}
+	return results, nil
+}
+
+// proxyLBs idsで指定されたProxyLBを返す、idsが空の場合は全てのProxyLBを返す
+func (s *Service) proxyLBs(ctx context.Context, ids []types.ID) ([]*iaas.ProxyLB, error) {
+	if len(ids) == 0 {
+		var results []*iaas.ProxyLB
+		for proxyLB, err := range s.FindIterWithContext(ctx, &FindRequest{}) {
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, proxyLB)
+		}
+		return results, nil
+	}
+
+	client := iaas.NewProxyLBOp(s.caller)
+	var results []*iaas.ProxyLB
+	for _, id := range ids {
+		proxyLB, err := client.Read(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, proxyLB)
+	}
+	return results, nil
+}
+
+func certificateExpiries(proxyLB *iaas.ProxyLB, certs *iaas.ProxyLBCertificates) []*CertificateExpiry {
+	if certs == nil {
+		return nil
+	}
+
+	var results []*CertificateExpiry
+	if cert := certs.PrimaryCert; cert != nil && cert.ServerCertificate != "" {
+		results = append(results, newCertificateExpiry(proxyLB, true,
+			cert.ServerCertificate, cert.CertificateCommonName, cert.CertificateAltNames, cert.CertificateEndDate))
+	}
+	for _, cert := range certs.AdditionalCerts {
+		if cert == nil || cert.ServerCertificate == "" {
+			continue
+		}
+		results = append(results, newCertificateExpiry(proxyLB, false,
+			cert.ServerCertificate, cert.CertificateCommonName, cert.CertificateAltNames, cert.CertificateEndDate))
+	}
+	return results
+}
+
+// newCertificateExpiry APIから返された有効期限などを元にCertificateExpiryを作成する
+//
+// 有効期限が返されなかった場合はサーバ証明書をパースして取得する
+func newCertificateExpiry(proxyLB *iaas.ProxyLB, primary bool, serverCert, commonName, altNames string, endDate time.Time) *CertificateExpiry {
+	expiry := &CertificateExpiry{
+		ProxyLBID:   proxyLB.ID,
+		ProxyLBName: proxyLB.Name,
+		Primary:     primary,
+		CommonName:  commonName,
+		NotAfter:    endDate,
+	}
+	for _, name := range strings.Split(altNames, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			expiry.AltNames = append(expiry.AltNames, name)
+		}
+	}
+
+	if block, _ := pem.Decode([]byte(serverCert)); block != nil && (endDate.IsZero() || commonName == "") {
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			if expiry.NotAfter.IsZero() {
+				expiry.NotAfter = cert.NotAfter
+			}
+			if expiry.CommonName == "" {
+				expiry.CommonName = cert.Subject.CommonName
+				expiry.AltNames = cert.DNSNames
+			}
+		}
+	}
+	return expiry
+}
@@ -0,0 +1,156 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxylb
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func (c *testCert) certPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw})
+}
+
+func (c *testCert) keyPEM(t *testing.T) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(c.key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func newTestCert(t *testing.T, cn string, parent *testCert, notBefore, notAfter time.Time) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		DNSNames:              []string{cn},
+	}
+
+	issuer, signer := tmpl, crypto.Signer(key)
+	if parent != nil {
+		issuer, signer = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, issuer, key.Public(), signer)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key}
+}
+
+func TestParseCertificatePEM(t *testing.T) {
+	now := time.Now()
+	root := newTestCert(t, "root", nil, now.Add(-time.Hour), now.Add(24*time.Hour))
+	inter1 := newTestCert(t, "intermediate1", root, now.Add(-time.Hour), now.Add(24*time.Hour))
+	inter2 := newTestCert(t, "intermediate2", inter1, now.Add(-time.Hour), now.Add(24*time.Hour))
+	leaf := newTestCert(t, "www.example.com", inter2, now.Add(-time.Hour), now.Add(time.Hour))
+	other := newTestCert(t, "other", nil, now.Add(-time.Hour), now.Add(time.Hour))
+
+	t.Run("chain in random order", func(t *testing.T) {
+		var chain []byte
+		for _, c := range []*testCert{inter1, root, leaf, inter2} {
+			chain = append(chain, c.certPEM()...)
+		}
+
+		cert, err := ParseCertificatePEM(chain, leaf.keyPEM(t))
+		require.NoError(t, err)
+		require.True(t, cert.Leaf.Equal(leaf.cert))
+		require.Len(t, cert.Intermediates, 2)
+		require.True(t, cert.Intermediates[0].Equal(inter2.cert))
+		require.True(t, cert.Intermediates[1].Equal(inter1.cert))
+		require.NoError(t, cert.Verify(now))
+
+		primary := cert.PrimaryCert()
+		require.Equal(t, string(leaf.certPEM()), primary.ServerCertificate)
+		require.Equal(t, string(inter2.certPEM())+string(inter1.certPEM()), primary.IntermediateCertificate)
+		require.Equal(t, string(leaf.keyPEM(t)), primary.PrivateKey)
+	})
+
+	t.Run("key mismatch", func(t *testing.T) {
+		_, err := ParseCertificatePEM(leaf.certPEM(), other.keyPEM(t))
+		require.EqualError(t, err, "private key does not match any certificate")
+	})
+
+	t.Run("no certificate", func(t *testing.T) {
+		_, err := ParseCertificatePEM(leaf.keyPEM(t), leaf.keyPEM(t))
+		require.EqualError(t, err, "no certificate found in PEM data")
+	})
+
+	t.Run("validity", func(t *testing.T) {
+		cert, err := ParseCertificatePEM(append(leaf.certPEM(), inter2.certPEM()...), leaf.keyPEM(t))
+		require.NoError(t, err)
+		require.ErrorContains(t, cert.Verify(now.Add(2*time.Hour)), `certificate "www.example.com" has expired`)
+		require.ErrorContains(t, cert.Verify(now.Add(-2*time.Hour)), `certificate "www.example.com" is not yet valid`)
+	})
+}
+
+func TestCertificateExpiries(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	leaf := newTestCert(t, "www.example.com", nil, now.Add(-time.Hour), now.Add(time.Hour))
+
+	proxyLB := &iaas.ProxyLB{ID: 1, Name: "example"}
+	expiries := certificateExpiries(proxyLB, &iaas.ProxyLBCertificates{
+		PrimaryCert: &iaas.ProxyLBPrimaryCert{
+			ServerCertificate:     "dummy",
+			CertificateCommonName: "example.com",
+			CertificateAltNames:   "example.com, www.example.com",
+			CertificateEndDate:    now.Add(48 * time.Hour),
+		},
+		AdditionalCerts: []*iaas.ProxyLBAdditionalCert{
+			{ServerCertificate: string(leaf.certPEM())},
+		},
+	})
+
+	require.Equal(t, []*CertificateExpiry{
+		{
+			ProxyLBID:   1,
+			ProxyLBName: "example",
+			Primary:     true,
+			CommonName:  "example.com",
+			AltNames:    []string{"example.com", "www.example.com"},
+			NotAfter:    now.Add(48 * time.Hour),
+		},
+		{
+			ProxyLBID:   1,
+			ProxyLBName: "example",
+			CommonName:  "www.example.com",
+			AltNames:    []string{"www.example.com"},
+			NotAfter:    leaf.cert.NotAfter,
+		},
+	}, expiries)
+
+	require.False(t, expiries[0].ExpiresWithin(now, 24*time.Hour))
+	require.True(t, expiries[1].ExpiresWithin(now, 24*time.Hour))
+}
@@ -0,0 +1,54 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxylb
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-service-go/serviceutil"
+)
+
+// Export 既存リソースをIDを保持したApplyRequestとしてJSONまたはYAML形式で出力する
+func (s *Service) Export(req *ExportRequest) ([]byte, error) {
+	return s.ExportWithContext(context.Background(), req)
+}
+
+// ExportWithContext 既存リソースをIDを保持したApplyRequestとしてJSONまたはYAML形式で出力する
+func (s *Service) ExportWithContext(ctx context.Context, req *ExportRequest) ([]byte, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	applyRequest, err := (&UpdateRequest{ID: req.ID}).ApplyRequest(ctx, s.caller)
+	if err != nil {
+		return nil, err
+	}
+	if !req.IncludePrivateKeys {
+		redactPrivateKeys(applyRequest)
+	}
+	return serviceutil.MarshalRequest(applyRequest, req.Format)
+}
+
+// redactPrivateKeys 証明書の秘密鍵を空にする
+//
+// 証明書の差分判定ではサーバ証明書と中間証明書のみを比較するため、秘密鍵を空にしたApplyRequestを再適用しても証明書は更新されない
+func redactPrivateKeys(req *ApplyRequest) {
+	if req.PrimaryCert != nil {
+		req.PrimaryCert.PrivateKey = ""
+	}
+	for _, cert := range req.AdditionalCerts {
+		cert.PrivateKey = ""
+	}
+}
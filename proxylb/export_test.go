@@ -0,0 +1,50 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxylb
+
+import (
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactPrivateKeys(t *testing.T) {
+	req := &ApplyRequest{
+		PrimaryCert: &iaas.ProxyLBPrimaryCert{
+			ServerCertificate:       "server",
+			IntermediateCertificate: "intermediate",
+			PrivateKey:              "private-key",
+		},
+		AdditionalCerts: []*iaas.ProxyLBAdditionalCert{
+			{
+				ServerCertificate: "additional",
+				PrivateKey:        "additional-private-key",
+			},
+		},
+	}
+	redactPrivateKeys(req)
+
+	require.Equal(t, &iaas.ProxyLBPrimaryCert{
+		ServerCertificate:       "server",
+		IntermediateCertificate: "intermediate",
+	}, req.PrimaryCert)
+	require.Equal(t, []*iaas.ProxyLBAdditionalCert{
+		{ServerCertificate: "additional"},
+	}, req.AdditionalCerts)
+
+	// 証明書が無い場合も安全に呼び出せる
+	redactPrivateKeys(&ApplyRequest{})
+}
@@ -0,0 +1,65 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxylb
+
+import (
+	"time"
+
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/packages-go/validate"
+)
+
+const (
+	// DefaultRenewBefore 証明書の有効期限のどれだけ前から更新を行うかのデフォルト値
+	DefaultRenewBefore = 30 * 24 * time.Hour
+	// DefaultRenewPollingInterval 更新後の証明書が反映されたか確認する間隔のデフォルト値
+	DefaultRenewPollingInterval = 30 * time.Second
+	// DefaultRenewTimeout 更新後の証明書が反映されるまで待つ時間のデフォルト値
+	DefaultRenewTimeout = 10 * time.Minute
+)
+
+type RenewLetsEncryptCertsRequest struct {
+	IDs []types.ID // 空の場合はLet's Encryptが有効な全てのProxyLBが対象
+
+	RenewBefore     time.Duration `validate:"min=0"` // 0の場合はDefaultRenewBefore
+	PollingInterval time.Duration `validate:"min=0"` // 0の場合はDefaultRenewPollingInterval
+	Timeout         time.Duration `validate:"min=0"` // 0の場合はDefaultRenewTimeout
+	DryRun          bool          // trueの場合は更新対象の判定のみ行う
+}
+
+func (req *RenewLetsEncryptCertsRequest) Validate() error {
+	return validate.New().Struct(req)
+}
+
+func (req *RenewLetsEncryptCertsRequest) renewBefore() time.Duration {
+	if req.RenewBefore == 0 {
+		return DefaultRenewBefore
+	}
+	return req.RenewBefore
+}
+
+func (req *RenewLetsEncryptCertsRequest) pollingInterval() time.Duration {
+	if req.PollingInterval == 0 {
+		return DefaultRenewPollingInterval
+	}
+	return req.PollingInterval
+}
+
+func (req *RenewLetsEncryptCertsRequest) timeout() time.Duration {
+	if req.Timeout == 0 {
+		return DefaultRenewTimeout
+	}
+	return req.Timeout
+}
@@ -0,0 +1,148 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxylb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/setup"
+)
+
+// RenewalOutcome 証明書更新の結果
+type RenewalOutcome string
+
+const (
+	// RenewalOutcomeRenewed 証明書が更新された
+	RenewalOutcomeRenewed RenewalOutcome = "renewed"
+	// RenewalOutcomeNotDue 有効期限まで十分な期間があるため更新しなかった
+	RenewalOutcomeNotDue RenewalOutcome = "not_due"
+	// RenewalOutcomeDue 更新が必要(DryRun時のみ)
+	RenewalOutcomeDue RenewalOutcome = "due"
+	// RenewalOutcomeFailed 更新に失敗した
+	RenewalOutcomeFailed RenewalOutcome = "failed"
+)
+
+// RenewalResult ProxyLBごとの証明書更新の結果
+type RenewalResult struct {
+	ProxyLBID   types.ID
+	ProxyLBName string
+	CommonName  string
+	Outcome     RenewalOutcome
+	NotAfter    time.Time // 更新前の有効期限、証明書が設定されていない場合はゼロ値
+	NewNotAfter time.Time // 更新後の有効期限、Outcomeがrenewedの場合のみ設定される
+	Err         error
+}
+
+func (s *Service) RenewLetsEncryptCerts(req *RenewLetsEncryptCertsRequest) ([]*RenewalResult, error) {
+	return s.RenewLetsEncryptCertsWithContext(context.Background(), req)
+}
+
+// RenewLetsEncryptCertsWithContext Let's Encryptが有効なProxyLBのうち有効期限が近いものについて証明書を更新する
+//
+// 更新を要求した後、新しい証明書が設定されるまで待つ。
+// 個々のProxyLBでの失敗はRenewalResult.Errとして返し、他のProxyLBの処理は継続する。
+func (s *Service) RenewLetsEncryptCertsWithContext(ctx context.Context, req *RenewLetsEncryptCertsRequest) ([]*RenewalResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	targets, err := s.proxyLBs(ctx, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	client := iaas.NewProxyLBOp(s.caller)
+	var results []*RenewalResult
+	for _, proxyLB := range targets {
+		if proxyLB.LetsEncrypt == nil || !proxyLB.LetsEncrypt.Enabled {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		results = append(results, renewLetsEncryptCert(ctx, client, proxyLB, req))
+	}
+	return results, nil
+}
+
+func renewLetsEncryptCert(ctx context.Context, client iaas.ProxyLBAPI, proxyLB *iaas.ProxyLB, req *RenewLetsEncryptCertsRequest) *RenewalResult {
+	result := &RenewalResult{
+		ProxyLBID:   proxyLB.ID,
+		ProxyLBName: proxyLB.Name,
+		CommonName:  proxyLB.LetsEncrypt.CommonName,
+	}
+	fail := func(err error) *RenewalResult {
+		result.Outcome = RenewalOutcomeFailed
+		result.Err = err
+		return result
+	}
+
+	current, err := primaryCertificateExpiry(ctx, client, proxyLB)
+	if err != nil {
+		return fail(err)
+	}
+	if current != nil {
+		result.NotAfter = current.NotAfter
+		if !current.ExpiresWithin(time.Now(), req.renewBefore()) {
+			result.Outcome = RenewalOutcomeNotDue
+			return result
+		}
+	}
+
+	if req.DryRun {
+		result.Outcome = RenewalOutcomeDue
+		return result
+	}
+
+	if err := client.RenewLetsEncryptCert(ctx, proxyLB.ID); err != nil {
+		return fail(fmt.Errorf("renewing certificate of ProxyLB[%s] failed: %s", proxyLB.ID, err))
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, req.timeout())
+	defer cancel()
+	for {
+		renewed, err := primaryCertificateExpiry(waitCtx, client, proxyLB)
+		if err != nil {
+			return fail(err)
+		}
+		if renewed != nil && renewed.NotAfter.After(result.NotAfter) {
+			result.Outcome = RenewalOutcomeRenewed
+			result.NewNotAfter = renewed.NotAfter
+			return result
+		}
+
+		if err := setup.Sleep(waitCtx, req.pollingInterval()); err != nil {
+			return fail(fmt.Errorf("waiting for renewed certificate of ProxyLB[%s] failed: %w", proxyLB.ID, err))
+		}
+	}
+}
+
+// primaryCertificateExpiry プライマリ証明書の有効期限を返す、証明書が設定されていない場合はnilを返す
+func primaryCertificateExpiry(ctx context.Context, client iaas.ProxyLBAPI, proxyLB *iaas.ProxyLB) (*CertificateExpiry, error) {
+	certs, err := client.GetCertificates(ctx, proxyLB.ID)
+	if err != nil {
+		return nil, fmt.Errorf("reading certificates of ProxyLB[%s] failed: %s", proxyLB.ID, err)
+	}
+	for _, expiry := range certificateExpiries(proxyLB, certs) {
+		if expiry.Primary {
+			return expiry, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxylb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+type dummyRenewProxyLBAPI struct {
+	iaas.ProxyLBAPI
+
+	notAfter     time.Time
+	renewedAfter time.Time // RenewLetsEncryptCert呼び出し後の有効期限
+	renewErr     error
+	pending      int // 更新後の証明書が反映されるまでのGetCertificatesの呼び出し回数
+	renewed      bool
+}
+
+func (d *dummyRenewProxyLBAPI) GetCertificates(ctx context.Context, id types.ID) (*iaas.ProxyLBCertificates, error) {
+	notAfter := d.notAfter
+	if d.renewed {
+		if d.pending > 0 {
+			d.pending--
+		} else {
+			notAfter = d.renewedAfter
+		}
+	}
+	if notAfter.IsZero() {
+		return &iaas.ProxyLBCertificates{}, nil
+	}
+	return &iaas.ProxyLBCertificates{
+		PrimaryCert: &iaas.ProxyLBPrimaryCert{
+			ServerCertificate:     "dummy",
+			CertificateCommonName: "www.example.com",
+			CertificateEndDate:    notAfter,
+		},
+	}, nil
+}
+
+func (d *dummyRenewProxyLBAPI) RenewLetsEncryptCert(ctx context.Context, id types.ID) error {
+	if d.renewErr != nil {
+		return d.renewErr
+	}
+	d.renewed = true
+	return nil
+}
+
+func TestRenewLetsEncryptCert(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	proxyLB := &iaas.ProxyLB{
+		ID:          1,
+		Name:        "example",
+		LetsEncrypt: &iaas.ProxyLBACMESetting{CommonName: "www.example.com", Enabled: true},
+	}
+	req := &RenewLetsEncryptCertsRequest{
+		RenewBefore:     7 * 24 * time.Hour,
+		PollingInterval: time.Millisecond,
+		Timeout:         time.Second,
+	}
+
+	cases := []struct {
+		msg     string
+		client  *dummyRenewProxyLBAPI
+		req     *RenewLetsEncryptCertsRequest
+		outcome RenewalOutcome
+		err     bool
+	}{
+		{
+			msg:     "not due",
+			client:  &dummyRenewProxyLBAPI{notAfter: now.Add(30 * 24 * time.Hour)},
+			req:     req,
+			outcome: RenewalOutcomeNotDue,
+		},
+		{
+			msg:     "renewed",
+			client:  &dummyRenewProxyLBAPI{notAfter: now.Add(24 * time.Hour), renewedAfter: now.Add(90 * 24 * time.Hour), pending: 3},
+			req:     req,
+			outcome: RenewalOutcomeRenewed,
+		},
+		{
+			msg:     "no certificate",
+			client:  &dummyRenewProxyLBAPI{renewedAfter: now.Add(90 * 24 * time.Hour)},
+			req:     req,
+			outcome: RenewalOutcomeRenewed,
+		},
+		{
+			msg:    "dry run",
+			client: &dummyRenewProxyLBAPI{notAfter: now.Add(24 * time.Hour)},
+			req: &RenewLetsEncryptCertsRequest{
+				RenewBefore: 7 * 24 * time.Hour,
+				DryRun:      true,
+			},
+			outcome: RenewalOutcomeDue,
+		},
+		{
+			msg:     "renew error",
+			client:  &dummyRenewProxyLBAPI{notAfter: now.Add(24 * time.Hour), renewErr: errors.New("dummy")},
+			req:     req,
+			outcome: RenewalOutcomeFailed,
+			err:     true,
+		},
+		{
+			msg:    "timeout",
+			client: &dummyRenewProxyLBAPI{notAfter: now.Add(24 * time.Hour), renewedAfter: now.Add(24 * time.Hour)},
+			req: &RenewLetsEncryptCertsRequest{
+				RenewBefore:     7 * 24 * time.Hour,
+				PollingInterval: time.Millisecond,
+				Timeout:         10 * time.Millisecond,
+			},
+			outcome: RenewalOutcomeFailed,
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		result := renewLetsEncryptCert(context.Background(), tc.client, proxyLB, tc.req)
+		require.Equal(t, tc.outcome, result.Outcome, tc.msg)
+		require.Equal(t, tc.err, result.Err != nil, tc.msg)
+		require.Equal(t, "www.example.com", result.CommonName, tc.msg)
+		if tc.outcome == RenewalOutcomeRenewed {
+			require.Equal(t, tc.client.renewedAfter, result.NewNotAfter, tc.msg)
+		}
+	}
+}
@@ -15,6 +15,8 @@
 package proxylb
 
 import (
+	"context"
+
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
 	"github.com/sacloud/iaas-service-go/serviceutil"
@@ -61,3 +63,33 @@ func (req *UpdateRequest) ToRequestParameter(current *iaas.ProxyLB) (*iaas.Proxy
 	}
 	return r, nil
 }
+
+func (req *UpdateRequest) ApplyRequest(ctx context.Context, caller iaas.APICaller) (*ApplyRequest, error) {
+	client := iaas.NewProxyLBOp(caller)
+	current, err := client.Read(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	applyRequest := &ApplyRequest{ID: req.ID}
+	if err := serviceutil.RequestConvertTo(current, applyRequest); err != nil {
+		return nil, err
+	}
+
+	// Let's Encryptが有効な場合、証明書は自動で更新されるため含めない
+	if applyRequest.LetsEncrypt == nil || !applyRequest.LetsEncrypt.Enabled {
+		certs, err := client.GetCertificates(ctx, req.ID)
+		if err != nil {
+			return nil, err
+		}
+		if certs != nil {
+			applyRequest.PrimaryCert = certs.PrimaryCert
+			applyRequest.AdditionalCerts = certs.AdditionalCerts
+		}
+	}
+
+	if err := serviceutil.RequestConvertTo(req, applyRequest); err != nil {
+		return nil, err
+	}
+	return applyRequest, nil
+}
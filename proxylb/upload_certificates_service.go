@@ -0,0 +1,52 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxylb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+func (s *Service) UploadCertificates(req *UploadCertificatesRequest) error {
+	return s.UploadCertificatesWithContext(context.Background(), req)
+}
+
+// UploadCertificatesWithContext 証明書の有効期間を検証した上でProxyLBへ設定する
+func (s *Service) UploadCertificatesWithContext(ctx context.Context, req *UploadCertificatesRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := req.PrimaryCert.Verify(now); err != nil {
+		return fmt.Errorf("invalid primary certificate: %s", err)
+	}
+	var additionalCerts []*iaas.ProxyLBAdditionalCert
+	for i, cert := range req.AdditionalCerts {
+		if err := cert.Verify(now); err != nil {
+			return fmt.Errorf("invalid additional certificate[%d]: %s", i, err)
+		}
+		additionalCerts = append(additionalCerts, cert.AdditionalCert())
+	}
+
+	return s.SetCertificatesWithContext(ctx, &SetCertificatesRequest{
+		ID:              req.ID,
+		PrimaryCerts:    req.PrimaryCert.PrimaryCert(),
+		AdditionalCerts: additionalCerts,
+	})
+}
@@ -0,0 +1,406 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ruledsl
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// Rule パケットフィルタ/VPCルータのファイアウォールのルール
+//
+// テキスト表現は以下の形式
+//
+//	<allow|deny> <tcp|udp|icmp|fragment|ip> [from [<network>|any] [port <port>]] [to [<network>|any] [port <port>]] [log] [# description]
+//
+// 例:
+//
+//	allow tcp from 10.0.0.0/8 port 1024-65535 to port 22
+//	deny ip
+type Rule struct {
+	Line int // Parseで読み込んだ場合の行番号
+
+	Action             types.Action
+	Protocol           types.Protocol
+	SourceNetwork      string
+	SourcePort         string
+	DestinationNetwork string // VPCルータのみ
+	DestinationPort    string
+	Logging            bool // VPCルータのみ
+	Description        string
+}
+
+// ParseError Parseで発生したエラー
+type ParseError struct {
+	Line int
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+var protocols = map[string]types.Protocol{
+	"tcp":      types.Protocols.TCP,
+	"udp":      types.Protocols.UDP,
+	"icmp":     types.Protocols.ICMP,
+	"fragment": types.Protocols.Fragment,
+	"ip":       types.Protocols.IP,
+}
+
+var actions = map[string]types.Action{
+	"allow": types.Actions.Allow,
+	"deny":  types.Actions.Deny,
+}
+
+// Parse テキストからルールを読み込む
+//
+// 1行に1ルールを記述する。空行と#で始まる行は無視する。
+// 不正な行がある場合は全ての行のエラーを*ParseErrorとしてまとめて返す。
+func Parse(text string) ([]*Rule, error) {
+	var rules []*Rule
+	var errs []error
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule, err := ParseRule(line)
+		if err != nil {
+			errs = append(errs, &ParseError{Line: lineNo, Err: err})
+			continue
+		}
+		rule.Line = lineNo
+		rules = append(rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return rules, nil
+}
+
+// ParseRule 1行分のルールを読み込む
+func ParseRule(line string) (*Rule, error) {
+	rule := &Rule{}
+	if body, description, found := strings.Cut(line, "#"); found {
+		line = body
+		rule.Description = strings.TrimSpace(description)
+	}
+
+	p := &ruleParser{tokens: strings.Fields(line)}
+
+	action, ok := actions[p.next()]
+	if !ok {
+		return nil, errors.New("rule must start with allow or deny")
+	}
+	rule.Action = action
+
+	v := p.next()
+	protocol, ok := protocols[v]
+	if !ok {
+		return nil, fmt.Errorf("invalid protocol %q: must be one of tcp/udp/icmp/fragment/ip", v)
+	}
+	rule.Protocol = protocol
+
+	seen := map[string]bool{}
+	for !p.eof() {
+		keyword := p.next()
+		if seen[keyword] {
+			return nil, fmt.Errorf("%q is specified more than once", keyword)
+		}
+		seen[keyword] = true
+
+		var err error
+		switch keyword {
+		case "from":
+			rule.SourceNetwork, rule.SourcePort, err = p.endpoint(protocol)
+		case "to":
+			rule.DestinationNetwork, rule.DestinationPort, err = p.endpoint(protocol)
+		case "log":
+			rule.Logging = true
+		default:
+			err = fmt.Errorf("unexpected token %q", keyword)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rule, nil
+}
+
+type ruleParser struct {
+	tokens []string
+}
+
+func (p *ruleParser) eof() bool {
+	return len(p.tokens) == 0
+}
+
+func (p *ruleParser) peek() string {
+	if p.eof() {
+		return ""
+	}
+	return strings.ToLower(p.tokens[0])
+}
+
+func (p *ruleParser) next() string {
+	t := p.peek()
+	if !p.eof() {
+		p.tokens = p.tokens[1:]
+	}
+	return t
+}
+
+// endpoint [<network>|any] [port <port>]を読み込む
+func (p *ruleParser) endpoint(protocol types.Protocol) (network, port string, err error) {
+	switch t := p.peek(); t {
+	case "", "from", "to", "log", "port":
+	default:
+		p.next()
+		if t != "any" {
+			if network, err = parseNetwork(t); err != nil {
+				return "", "", err
+			}
+		}
+	}
+
+	if p.peek() == "port" {
+		p.next()
+		if !hasPort(protocol) {
+			return "", "", fmt.Errorf("port cannot be specified for protocol %s", protocol)
+		}
+		if port, err = parsePort(p.next()); err != nil {
+			return "", "", err
+		}
+	}
+	return network, port, nil
+}
+
+func hasPort(protocol types.Protocol) bool {
+	return protocol == types.Protocols.TCP || protocol == types.Protocols.UDP
+}
+
+// parseNetwork A.B.C.D、A.B.C.D/N、A.B.C.D/M.M.M.Mの形式のネットワークを検証する
+func parseNetwork(v string) (string, error) {
+	addr, mask, hasMask := strings.Cut(v, "/")
+	if _, err := netip.ParseAddr(addr); err != nil {
+		return "", fmt.Errorf("invalid network %q", v)
+	}
+	if hasMask {
+		if bits, err := strconv.Atoi(mask); err != nil {
+			if _, err := netip.ParseAddr(mask); err != nil {
+				return "", fmt.Errorf("invalid network %q", v)
+			}
+		} else if bits < 0 || bits > 32 {
+			return "", fmt.Errorf("invalid network %q", v)
+		}
+	}
+	return v, nil
+}
+
+// parsePort N、N-Mの形式のポートを検証する
+func parsePort(v string) (string, error) {
+	if v == "" {
+		return "", errors.New("port number is required after port")
+	}
+	from, to, isRange := strings.Cut(v, "-")
+	f, err := strconv.Atoi(from)
+	if err != nil || f < 0 || f > 65535 {
+		return "", fmt.Errorf("invalid port %q", v)
+	}
+	if isRange {
+		t, err := strconv.Atoi(to)
+		if err != nil || t < f || t > 65535 {
+			return "", fmt.Errorf("invalid port %q", v)
+		}
+	}
+	return v, nil
+}
+
+// String ルールをテキスト表現に変換する
+func (r *Rule) String() string {
+	var sb strings.Builder
+	sb.WriteString(string(r.Action))
+	sb.WriteString(" ")
+	sb.WriteString(string(r.Protocol))
+
+	endpoint := func(keyword, network, port string) {
+		if network == "" && port == "" {
+			return
+		}
+		sb.WriteString(" " + keyword)
+		if network != "" {
+			sb.WriteString(" " + network)
+		}
+		if port != "" {
+			sb.WriteString(" port " + port)
+		}
+	}
+	endpoint("from", r.SourceNetwork, r.SourcePort)
+	endpoint("to", r.DestinationNetwork, r.DestinationPort)
+
+	if r.Logging {
+		sb.WriteString(" log")
+	}
+	if r.Description != "" {
+		sb.WriteString(" # " + r.Description)
+	}
+	return sb.String()
+}
+
+// Format ルールを1行に1ルールのテキスト表現に変換する
+func Format(rules []*Rule) string {
+	var sb strings.Builder
+	for _, r := range rules {
+		sb.WriteString(r.String())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// PacketFilterExpressions ルールをパケットフィルタのルールに変換する
+//
+// packetfilter.CreateRequest/UpdateRequestのExpressionとして利用できる
+func PacketFilterExpressions(rules []*Rule) ([]*iaas.PacketFilterExpression, error) {
+	var expressions []*iaas.PacketFilterExpression
+	for _, r := range rules {
+		if r.DestinationNetwork != "" {
+			return nil, r.error("destination network is not supported in packet filter")
+		}
+		if r.Logging {
+			return nil, r.error("log is not supported in packet filter")
+		}
+		expressions = append(expressions, &iaas.PacketFilterExpression{
+			Protocol:        r.Protocol,
+			SourceNetwork:   types.PacketFilterNetwork(r.SourceNetwork),
+			SourcePort:      types.PacketFilterPort(r.SourcePort),
+			DestinationPort: types.PacketFilterPort(r.DestinationPort),
+			Action:          r.Action,
+			Description:     r.Description,
+		})
+	}
+	return expressions, nil
+}
+
+// FromPacketFilterExpressions パケットフィルタのルールをルールに変換する
+func FromPacketFilterExpressions(expressions []*iaas.PacketFilterExpression) []*Rule {
+	var rules []*Rule
+	for _, e := range expressions {
+		rules = append(rules, &Rule{
+			Action:          e.Action,
+			Protocol:        e.Protocol,
+			SourceNetwork:   string(e.SourceNetwork),
+			SourcePort:      string(e.SourcePort),
+			DestinationPort: string(e.DestinationPort),
+			Description:     e.Description,
+		})
+	}
+	return rules
+}
+
+// VPCRouterFirewallRules ルールをVPCルータのファイアウォールのルールに変換する
+func VPCRouterFirewallRules(rules []*Rule) ([]*iaas.VPCRouterFirewallRule, error) {
+	var results []*iaas.VPCRouterFirewallRule
+	for _, r := range rules {
+		if r.Protocol == types.Protocols.Fragment {
+			return nil, r.error("protocol fragment is not supported in VPC router firewall")
+		}
+		results = append(results, &iaas.VPCRouterFirewallRule{
+			Protocol:           r.Protocol,
+			SourceNetwork:      types.VPCFirewallNetwork(r.SourceNetwork),
+			SourcePort:         types.VPCFirewallPort(r.SourcePort),
+			DestinationNetwork: types.VPCFirewallNetwork(r.DestinationNetwork),
+			DestinationPort:    types.VPCFirewallPort(r.DestinationPort),
+			Action:             r.Action,
+			Logging:            r.Logging,
+			Description:        r.Description,
+		})
+	}
+	return results, nil
+}
+
+// FromVPCRouterFirewallRules VPCルータのファイアウォールのルールをルールに変換する
+func FromVPCRouterFirewallRules(firewallRules []*iaas.VPCRouterFirewallRule) []*Rule {
+	var rules []*Rule
+	for _, r := range firewallRules {
+		rules = append(rules, &Rule{
+			Action:             r.Action,
+			Protocol:           r.Protocol,
+			SourceNetwork:      string(r.SourceNetwork),
+			SourcePort:         string(r.SourcePort),
+			DestinationNetwork: string(r.DestinationNetwork),
+			DestinationPort:    string(r.DestinationPort),
+			Logging:            r.Logging,
+			Description:        r.Description,
+		})
+	}
+	return rules
+}
+
+// VPCRouterFirewall 送信方向/受信方向のルールのテキスト表現からVPCルータのファイアウォール設定を作成する
+//
+// indexはファイアウォールを設定するインターフェースのインデックス。
+// vpcrouter/builder.RouterSetting.Firewallの要素として利用できる
+func VPCRouterFirewall(index int, send, receive string) (*iaas.VPCRouterFirewall, error) {
+	firewall := &iaas.VPCRouterFirewall{Index: index}
+	for _, direction := range []struct {
+		name string
+		text string
+		dest *[]*iaas.VPCRouterFirewallRule
+	}{
+		{name: "send", text: send, dest: &firewall.Send},
+		{name: "receive", text: receive, dest: &firewall.Receive},
+	} {
+		rules, err := Parse(direction.text)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", direction.name, err)
+		}
+		if *direction.dest, err = VPCRouterFirewallRules(rules); err != nil {
+			return nil, fmt.Errorf("%s: %w", direction.name, err)
+		}
+	}
+	return firewall, nil
+}
+
+// FormatVPCRouterFirewall VPCルータのファイアウォール設定を送信方向/受信方向のルールのテキスト表現に変換する
+func FormatVPCRouterFirewall(firewall *iaas.VPCRouterFirewall) (send, receive string) {
+	return Format(FromVPCRouterFirewallRules(firewall.Send)), Format(FromVPCRouterFirewallRules(firewall.Receive))
+}
+
+func (r *Rule) error(msg string) error {
+	if r.Line > 0 {
+		return &ParseError{Line: r.Line, Err: errors.New(msg)}
+	}
+	return errors.New(msg)
+}
@@ -0,0 +1,161 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ruledsl
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testRules = `
+# web server
+allow tcp from 10.0.0.0/8 port 1024-65535 to port 22 # ssh from internal
+allow tcp to port 80
+ALLOW UDP FROM any PORT 53
+allow icmp from 192.0.2.0/255.255.255.0
+allow fragment
+deny ip
+`
+
+func TestParse(t *testing.T) {
+	rules, err := Parse(testRules)
+	require.NoError(t, err)
+
+	expected := []*Rule{
+		{
+			Line:            3,
+			Action:          types.Actions.Allow,
+			Protocol:        types.Protocols.TCP,
+			SourceNetwork:   "10.0.0.0/8",
+			SourcePort:      "1024-65535",
+			DestinationPort: "22",
+			Description:     "ssh from internal",
+		},
+		{Line: 4, Action: types.Actions.Allow, Protocol: types.Protocols.TCP, DestinationPort: "80"},
+		{Line: 5, Action: types.Actions.Allow, Protocol: types.Protocols.UDP, SourcePort: "53"},
+		{Line: 6, Action: types.Actions.Allow, Protocol: types.Protocols.ICMP, SourceNetwork: "192.0.2.0/255.255.255.0"},
+		{Line: 7, Action: types.Actions.Allow, Protocol: types.Protocols.Fragment},
+		{Line: 8, Action: types.Actions.Deny, Protocol: types.Protocols.IP},
+	}
+	require.Equal(t, expected, rules)
+
+	// round trip
+	formatted := Format(rules)
+	require.Equal(t, `allow tcp from 10.0.0.0/8 port 1024-65535 to port 22 # ssh from internal
+allow tcp to port 80
+allow udp from port 53
+allow icmp from 192.0.2.0/255.255.255.0
+allow fragment
+deny ip
+`, formatted)
+
+	reparsed, err := Parse(formatted)
+	require.NoError(t, err)
+	for i := range reparsed {
+		reparsed[i].Line = rules[i].Line
+	}
+	require.Equal(t, rules, reparsed)
+}
+
+func TestParse_errors(t *testing.T) {
+	_, err := Parse(`allow tcp to port 22
+permit tcp
+allow http
+allow icmp to port 80
+allow tcp from 10.0.0.0/33
+allow tcp to port 80-22
+allow tcp to port
+allow tcp from any from any
+deny ip everything`)
+	require.Error(t, err)
+
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+	require.Equal(t, 2, parseErr.Line)
+
+	require.Equal(t, `line 2: rule must start with allow or deny
+line 3: invalid protocol "http": must be one of tcp/udp/icmp/fragment/ip
+line 4: port cannot be specified for protocol icmp
+line 5: invalid network "10.0.0.0/33"
+line 6: invalid port "80-22"
+line 7: port number is required after port
+line 8: "from" is specified more than once
+line 9: unexpected token "everything"`, err.Error())
+}
+
+func TestPacketFilterExpressions(t *testing.T) {
+	rules, err := Parse(testRules)
+	require.NoError(t, err)
+
+	expressions, err := PacketFilterExpressions(rules)
+	require.NoError(t, err)
+	require.Len(t, expressions, 6)
+	require.Equal(t, &iaas.PacketFilterExpression{
+		Protocol:        types.Protocols.TCP,
+		SourceNetwork:   "10.0.0.0/8",
+		SourcePort:      "1024-65535",
+		DestinationPort: "22",
+		Action:          types.Actions.Allow,
+		Description:     "ssh from internal",
+	}, expressions[0])
+	require.Equal(t, Format(rules), Format(FromPacketFilterExpressions(expressions)))
+
+	rules, err = Parse("\nallow tcp to 192.0.2.1 port 80")
+	require.NoError(t, err)
+	_, err = PacketFilterExpressions(rules)
+	require.EqualError(t, err, "line 2: destination network is not supported in packet filter")
+}
+
+func TestVPCRouterFirewall(t *testing.T) {
+	firewall, err := VPCRouterFirewall(1,
+		"allow tcp from 192.168.0.0/24 to 0.0.0.0/0 port 443 log\ndeny ip",
+		"allow tcp to 192.168.0.10 port 80\ndeny ip log",
+	)
+	require.NoError(t, err)
+	require.Equal(t, &iaas.VPCRouterFirewall{
+		Index: 1,
+		Send: []*iaas.VPCRouterFirewallRule{
+			{
+				Protocol:           types.Protocols.TCP,
+				SourceNetwork:      "192.168.0.0/24",
+				DestinationNetwork: "0.0.0.0/0",
+				DestinationPort:    "443",
+				Action:             types.Actions.Allow,
+				Logging:            true,
+			},
+			{Protocol: types.Protocols.IP, Action: types.Actions.Deny},
+		},
+		Receive: []*iaas.VPCRouterFirewallRule{
+			{
+				Protocol:           types.Protocols.TCP,
+				DestinationNetwork: "192.168.0.10",
+				DestinationPort:    "80",
+				Action:             types.Actions.Allow,
+			},
+			{Protocol: types.Protocols.IP, Action: types.Actions.Deny, Logging: true},
+		},
+	}, firewall)
+
+	send, receive := FormatVPCRouterFirewall(firewall)
+	require.Equal(t, "allow tcp from 192.168.0.0/24 to 0.0.0.0/0 port 443 log\ndeny ip\n", send)
+	require.Equal(t, "allow tcp to 192.168.0.10 port 80\ndeny ip log\n", receive)
+
+	_, err = VPCRouterFirewall(0, "", "allow fragment")
+	require.EqualError(t, err, "receive: line 1: protocol fragment is not supported in VPC router firewall")
+}
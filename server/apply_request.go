@@ -52,6 +52,8 @@ type ApplyRequest struct {
 	NoWait            bool
 
 	ForceShutdown bool
+
+	RollbackOnFailure bool // trueの場合、作成に失敗した際に作成済みのディスク/NIC/サーバを削除する
 }
 
 func (req *ApplyRequest) Validate() error {
@@ -122,5 +124,7 @@ func (req *ApplyRequest) Builder(caller iaas.APICaller) (*server.Builder, error)
 		ServerID:        req.ID,
 		ForceShutdown:   req.ForceShutdown,
 		NoWait:          req.NoWait,
+
+		RollbackOnFailure: req.RollbackOnFailure,
 	}, nil
 }
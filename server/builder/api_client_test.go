@@ -121,6 +121,10 @@ func (d *dummyCreateServerHandler) Update(ctx context.Context, zone string, id t
 	return d.server, nil
 }
 
+func (d *dummyCreateServerHandler) Delete(ctx context.Context, zone string, id types.ID) error {
+	return d.err
+}
+
 func (d *dummyCreateServerHandler) InsertCDROM(ctx context.Context, zone string, id types.ID, insertParam *iaas.InsertCDROMRequest) error {
 	return d.cdromErr
 }
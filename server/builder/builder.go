@@ -27,6 +27,7 @@ import (
 	"github.com/sacloud/iaas-api-go/types"
 	service "github.com/sacloud/iaas-service-go"
 	disk "github.com/sacloud/iaas-service-go/disk/builder"
+	"github.com/sacloud/iaas-service-go/progress"
 	"github.com/sacloud/packages-go/size"
 )
 
@@ -60,6 +61,9 @@ type Builder struct {
 
 	ServerID      types.ID
 	ForceShutdown bool
+
+	// RollbackOnFailure trueの場合、Build失敗時に作成済みのディスク/NIC/サーバを削除する
+	RollbackOnFailure bool
 }
 
 func BuilderFromResource(ctx context.Context, caller iaas.APICaller, zone string, id types.ID) (*Builder, error) {
@@ -152,8 +156,9 @@ func BuilderFromResource(ctx context.Context, caller iaas.APICaller, zone string
 
 // BuildResult サーバ構築結果
 type BuildResult struct {
-	ServerID types.ID
-	DiskIDs  []types.ID
+	ServerID         types.ID
+	DiskIDs          []types.ID
+	ConnectedDiskIDs []types.ID // DiskIDsのうち既存ディスクを接続したもの
 }
 
 var (
@@ -234,6 +239,9 @@ func (b *Builder) Validate(ctx context.Context, zone string) error {
 }
 
 // Build サーバ構築を行う
+//
+// RollbackOnFailureがtrueの場合、サーバ作成後の処理で失敗した際に作成済みのリソースを削除する。
+// 削除に成功した場合はnilと元のエラーを、削除に失敗した場合は構築結果と元のエラー/削除時のエラーを返す。
 func (b *Builder) Build(ctx context.Context, zone string) (*BuildResult, error) {
 	// validate
 	if err := b.Validate(ctx, zone); err != nil {
@@ -248,38 +256,61 @@ func (b *Builder) Build(ctx context.Context, zone string) (*BuildResult, error)
 	result := &BuildResult{
 		ServerID: server.ID,
 	}
+	progress.Emit(ctx, &progress.Event{Type: progress.EventCreated, Resource: "Server", Zone: zone, ID: server.ID})
 
+	if err := b.setupServer(ctx, zone, server, result); err != nil {
+		if !b.RollbackOnFailure {
+			return result, err
+		}
+		if rollbackErr := b.rollback(context.WithoutCancel(ctx), zone, result); rollbackErr != nil {
+			return result, errors.Join(err, fmt.Errorf("rollback failed: %w", rollbackErr))
+		}
+		return nil, err
+	}
+
+	b.ServerID = result.ServerID
+	progress.Emit(ctx, &progress.Event{Type: progress.EventReady, Resource: "Server", Zone: zone, ID: server.ID})
+	return result, nil
+}
+
+// setupServer 作成したサーバへのディスクの作成/接続、NICの設定、CD-ROMの挿入、起動を行う
+func (b *Builder) setupServer(ctx context.Context, zone string, server *iaas.Server, result *BuildResult) error {
 	// create&connect disk(s)
 	for _, diskReq := range b.DiskBuilders {
 		builtDisk, err := diskReq.Build(ctx, zone, server.ID)
+		// 作成/待機に失敗した場合も作成済みのディスクをロールバック対象とするためエラーの判定より先に記録する
+		if builtDisk != nil {
+			result.DiskIDs = append(result.DiskIDs, builtDisk.DiskID)
+			if _, ok := diskReq.(*disk.ConnectedDiskBuilder); ok {
+				result.ConnectedDiskIDs = append(result.ConnectedDiskIDs, builtDisk.DiskID)
+			}
+		}
 		if err != nil {
-			return result, err
+			return err
 		}
-		result.DiskIDs = append(result.DiskIDs, builtDisk.DiskID)
 	}
 
 	// connect packet filter
 	if err := b.updateInterfaces(ctx, zone, server); err != nil {
-		return result, err
+		return err
 	}
 
 	// insert CD-ROM
 	if !b.CDROMID.IsEmpty() {
 		req := &iaas.InsertCDROMRequest{ID: b.CDROMID}
 		if err := b.Client.Server.InsertCDROM(ctx, zone, server.ID, req); err != nil {
-			return result, err
+			return err
 		}
 	}
 
 	// bool
 	if !b.NoWait && b.BootAfterCreate {
+		progress.Emit(ctx, &progress.Event{Type: progress.EventBooting, Resource: "Server", Zone: zone, ID: server.ID})
 		if err := power.BootServer(ctx, b.Client.Server, zone, server.ID, b.userData()...); err != nil {
-			return result, err
+			return err
 		}
 	}
-
-	b.ServerID = result.ServerID
-	return result, nil
+	return nil
 }
 
 // IsNeedShutdown Update時にシャットダウンが必要か
@@ -292,9 +323,12 @@ func (b *Builder) IsNeedShutdown(ctx context.Context, zone string) (bool, error)
 	if err != nil {
 		return false, err
 	}
+	return b.isNeedShutdown(ctx, zone, server), nil
+}
 
+func (b *Builder) isNeedShutdown(ctx context.Context, zone string, server *iaas.Server) bool {
 	if b.UserData != "" {
-		return true, nil
+		return true
 	}
 
 	current := b.currentState(server)
@@ -309,7 +343,7 @@ func (b *Builder) IsNeedShutdown(ctx context.Context, zone string) (bool, error)
 	b.fillDummyValueToState(nics...)
 
 	if !reflect.DeepEqual(current, desired) {
-		return true, nil
+		return true
 	}
 
 	// ここに到達するときはserver.Disksとb.DiskBuildersは同数となっている
@@ -327,10 +361,10 @@ func (b *Builder) IsNeedShutdown(ctx context.Context, zone string) (bool, error)
 		})
 
 		if level == service.UpdateLevelNeedShutdown {
-			return true, nil
+			return true
 		}
 	}
-	return false, nil
+	return false
 }
 
 func (b *Builder) fillDummyValueToState(state ...*nicState) {
@@ -660,36 +694,17 @@ func (b *Builder) updateInterfaces(ctx context.Context, zone string, server *iaa
 
 func (b *Builder) reconcileDisks(ctx context.Context, zone string, server *iaas.Server, result *BuildResult) error {
 	// reconcile disks
-	isDiskUpdated := len(server.Disks) != len(b.DiskBuilders) // isDiskUpdateがtrueの場合、後でディスクの取外&接続を行う
-	for i, diskReq := range b.DiskBuilders {
-		if diskReq.DiskID().IsEmpty() {
-			_, err := diskReq.Build(ctx, zone, server.ID)
-			if err != nil {
+	changes, isDiskUpdated := b.diskChanges(ctx, zone, server) // isDiskUpdateがtrueの場合、後でディスクの取外&接続を行う
+	for _, change := range changes {
+		diskReq := b.DiskBuilders[change.Index]
+		switch change.Action {
+		case DiskActionCreate:
+			if _, err := diskReq.Build(ctx, zone, server.ID); err != nil {
 				return err
 			}
-			isDiskUpdated = true
-		}
-		if len(server.Disks) > i {
-			disk := server.Disks[i]
-			level := diskReq.UpdateLevel(ctx, zone, &iaas.Disk{
-				ID:              disk.ID,
-				Name:            disk.Name,
-				Availability:    disk.Availability,
-				Connection:      disk.Connection,
-				ConnectionOrder: disk.ConnectionOrder,
-				ReinstallCount:  disk.ReinstallCount,
-				SizeMB:          disk.SizeMB,
-				DiskPlanID:      disk.DiskPlanID,
-				Storage:         disk.Storage,
-			})
-			if level != service.UpdateLevelNone {
-				_, err := diskReq.Update(ctx, zone)
-				if err != nil {
-					return err
-				}
-			}
-			if disk.ID != diskReq.DiskID() {
-				isDiskUpdated = true
+		case DiskActionUpdate:
+			if _, err := diskReq.Update(ctx, zone); err != nil {
+				return err
 			}
 		}
 	}
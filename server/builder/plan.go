@@ -0,0 +1,373 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+)
+
+// ChangeSet Build/Update時に行われる変更内容
+type ChangeSet struct {
+	// ServerID 更新対象のサーバのID、新規作成の場合は空
+	ServerID types.ID
+	// Create サーバが新規作成されるか
+	Create bool
+	// IsNeedShutdown 変更の反映にシャットダウンが必要か
+	IsNeedShutdown bool
+
+	Fields     []*FieldChange
+	PlanChange *PlanChange
+	NICs       []*NICChange
+	Disks      []*DiskChange
+}
+
+// HasChanges 変更があるか
+func (c *ChangeSet) HasChanges() bool {
+	return c.Create || len(c.Fields) > 0 || c.PlanChange != nil || len(c.NICs) > 0 || len(c.Disks) > 0
+}
+
+// FieldChange フィールドの変更内容
+type FieldChange struct {
+	Field   string
+	Current interface{}
+	Desired interface{}
+}
+
+// ServerPlan サーバプランを表す値
+type ServerPlan struct {
+	CPU            int
+	MemoryGB       int
+	GPU            int
+	GPUModel       string
+	CPUModel       string
+	Commitment     types.ECommitment
+	Generation     types.EPlanGeneration
+	ConfidentialVM bool
+}
+
+// PlanChange サーバプランの変更内容
+type PlanChange struct {
+	Current *ServerPlan
+	Desired *ServerPlan
+}
+
+// NICAction NICに対する操作
+type NICAction string
+
+const (
+	// NICActionCreate NICの追加
+	NICActionCreate = NICAction("create")
+	// NICActionDelete NICの削除
+	NICActionDelete = NICAction("delete")
+	// NICActionConnectToSharedSegment 共有セグメントへの接続
+	NICActionConnectToSharedSegment = NICAction("connect-to-shared-segment")
+	// NICActionConnectToSwitch スイッチへの接続
+	NICActionConnectToSwitch = NICAction("connect-to-switch")
+	// NICActionDisconnectFromSwitch スイッチからの切断
+	NICActionDisconnectFromSwitch = NICAction("disconnect-from-switch")
+	// NICActionConnectToPacketFilter パケットフィルタの接続
+	NICActionConnectToPacketFilter = NICAction("connect-to-packet-filter")
+	// NICActionDisconnectFromPacketFilter パケットフィルタの切断
+	NICActionDisconnectFromPacketFilter = NICAction("disconnect-from-packet-filter")
+	// NICActionUpdateDisplayIPAddress 表示用IPアドレスの更新
+	NICActionUpdateDisplayIPAddress = NICAction("update-display-ip-address")
+)
+
+// NICChange NICの変更内容
+type NICChange struct {
+	Index  int // NICのインデックス(0: eth0)
+	Action NICAction
+
+	SwitchID         types.ID // NICActionConnectToSwitch/NICActionDisconnectFromSwitchの場合の対象スイッチ
+	PacketFilterID   types.ID // NICActionConnectToPacketFilter/NICActionDisconnectFromPacketFilterの場合の対象パケットフィルタ
+	DisplayIPAddress string   // NICActionUpdateDisplayIPAddressの場合の更新後の値
+}
+
+// DiskAction ディスクに対する操作
+type DiskAction string
+
+const (
+	// DiskActionCreate ディスクの作成と接続
+	DiskActionCreate = DiskAction("create")
+	// DiskActionUpdate ディスクの更新
+	DiskActionUpdate = DiskAction("update")
+	// DiskActionConnect ディスクの接続
+	DiskActionConnect = DiskAction("connect")
+	// DiskActionDisconnect ディスクの切断(ディスクは削除されない)
+	DiskActionDisconnect = DiskAction("disconnect")
+)
+
+// DiskChange ディスクの変更内容
+type DiskChange struct {
+	Index       int // DiskBuildersのインデックス、DiskActionDisconnectの場合は現在の接続順
+	Action      DiskAction
+	DiskID      types.ID            // 既存ディスクのID、作成するディスクの場合は空
+	UpdateLevel service.UpdateLevel // DiskActionUpdateの場合の更新レベル
+}
+
+// Plan Build/Update時に行われる変更内容を返す
+//
+// 参照系以外のAPIは呼び出さない
+func (b *Builder) Plan(ctx context.Context, zone string) (*ChangeSet, error) {
+	if err := b.Validate(ctx, zone); err != nil {
+		return nil, err
+	}
+
+	if b.ServerID.IsEmpty() {
+		return b.planForCreate(), nil
+	}
+
+	server, err := b.Client.Server.Read(ctx, zone, b.ServerID)
+	if err != nil {
+		return nil, err
+	}
+	if server.ConfidentialVM != b.ConfidentialVM {
+		return nil, fmt.Errorf("ConfidentialVM cannot be changed on update")
+	}
+
+	changeSet := &ChangeSet{
+		ServerID:       server.ID,
+		IsNeedShutdown: b.isNeedShutdown(ctx, zone, server),
+		Fields:         b.planFields(server),
+		NICs:           b.planInterfaces(server),
+		Disks:          b.planDisks(ctx, zone, server),
+	}
+	if b.isPlanChanged(server) {
+		changeSet.PlanChange = &PlanChange{
+			Current: &ServerPlan{
+				CPU:            server.CPU,
+				MemoryGB:       server.GetMemoryGB(),
+				GPU:            server.GPU,
+				GPUModel:       server.GPUModel,
+				CPUModel:       server.CPUModel,
+				Commitment:     server.Commitment,
+				Generation:     server.Generation,
+				ConfidentialVM: server.ConfidentialVM,
+			},
+			Desired: b.desiredPlan(),
+		}
+	}
+	return changeSet, nil
+}
+
+func (b *Builder) desiredPlan() *ServerPlan {
+	return &ServerPlan{
+		CPU:            b.CPU,
+		MemoryGB:       b.MemoryGB,
+		GPU:            b.GPU,
+		GPUModel:       b.GPUModel,
+		CPUModel:       b.CPUModel,
+		Commitment:     b.Commitment,
+		Generation:     b.Generation,
+		ConfidentialVM: b.ConfidentialVM,
+	}
+}
+
+func (b *Builder) planForCreate() *ChangeSet {
+	changeSet := &ChangeSet{
+		Create:     true,
+		PlanChange: &PlanChange{Desired: b.desiredPlan()},
+		Fields: []*FieldChange{
+			{Field: "Name", Desired: b.Name},
+			{Field: "Description", Desired: b.Description},
+			{Field: "Tags", Desired: b.Tags},
+			{Field: "IconID", Desired: b.IconID},
+			{Field: "PrivateHostID", Desired: b.PrivateHostID},
+			{Field: "InterfaceDriver", Desired: b.InterfaceDriver},
+			{Field: "CDROMID", Desired: b.CDROMID},
+		},
+	}
+
+	desired := b.desiredState()
+	desiredNICs := []*nicState{desired.nic}
+	desiredNICs = append(desiredNICs, desired.additionalNICs...)
+	for i, nic := range desiredNICs {
+		if nic == nil {
+			continue
+		}
+		changeSet.NICs = append(changeSet.NICs, &NICChange{Index: i, Action: NICActionCreate})
+		changeSet.NICs = append(changeSet.NICs, b.planInterfaceConnection(i, nil, nic)...)
+	}
+
+	for i, diskReq := range b.DiskBuilders {
+		action := DiskActionCreate
+		if !diskReq.DiskID().IsEmpty() {
+			action = DiskActionConnect
+		}
+		changeSet.Disks = append(changeSet.Disks, &DiskChange{Index: i, Action: action, DiskID: diskReq.DiskID()})
+	}
+	return changeSet
+}
+
+func (b *Builder) planFields(server *iaas.Server) []*FieldChange {
+	var changes []*FieldChange
+	add := func(field string, current, desired interface{}, changed bool) {
+		if changed {
+			changes = append(changes, &FieldChange{Field: field, Current: current, Desired: desired})
+		}
+	}
+
+	add("Name", server.Name, b.Name, server.Name != b.Name)
+	add("Description", server.Description, b.Description, server.Description != b.Description)
+	add("Tags", server.Tags, b.Tags, !tagsEqual(server.Tags, b.Tags))
+	add("IconID", server.IconID, b.IconID, server.IconID != b.IconID)
+	add("PrivateHostID", server.PrivateHostID, b.PrivateHostID, server.PrivateHostID != b.PrivateHostID)
+	add("InterfaceDriver", server.InterfaceDriver, b.InterfaceDriver, server.InterfaceDriver != b.InterfaceDriver)
+	add("CDROMID", server.CDROMID, b.CDROMID, !b.CDROMID.IsEmpty() && server.CDROMID != b.CDROMID)
+	return changes
+}
+
+func tagsEqual(t1, t2 types.Tags) bool {
+	if len(t1) != len(t2) {
+		return false
+	}
+	exists := map[string]bool{}
+	for _, t := range t1 {
+		exists[t] = true
+	}
+	for _, t := range t2 {
+		if !exists[t] {
+			return false
+		}
+	}
+	return true
+}
+
+// planInterfaces reconcileInterfacesで行われる操作を返す
+func (b *Builder) planInterfaces(server *iaas.Server) []*NICChange {
+	var changes []*NICChange
+
+	desiredState := b.desiredState()
+	desiredNICs := []*nicState{desiredState.nic}
+	desiredNICs = append(desiredNICs, desiredState.additionalNICs...)
+
+	for i, nic := range server.Interfaces {
+		var desired *nicState
+		if len(desiredNICs) > i {
+			desired = desiredNICs[i]
+		}
+
+		if desired == nil {
+			if !nic.SwitchID.IsEmpty() {
+				changes = append(changes, &NICChange{Index: i, Action: NICActionDisconnectFromSwitch, SwitchID: nic.SwitchID})
+			}
+			changes = append(changes, &NICChange{Index: i, Action: NICActionDelete})
+			continue
+		}
+		changes = append(changes, b.planInterfaceConnection(i, b.currentNICState(nic), desired)...)
+	}
+
+	for i, desired := range desiredNICs {
+		if desired == nil || i < len(server.Interfaces) {
+			continue
+		}
+		changes = append(changes, &NICChange{Index: i, Action: NICActionCreate})
+		changes = append(changes, b.planInterfaceConnection(i, nil, desired)...)
+	}
+	return changes
+}
+
+// planInterfaceConnection NICの接続先/パケットフィルタ/表示用IPアドレスの変更内容を返す
+//
+// currentがnilの場合は新規作成されたNICとして扱う
+func (b *Builder) planInterfaceConnection(index int, current, desired *nicState) []*NICChange {
+	if current == nil {
+		current = (&DisconnectedNICSetting{}).state()
+	}
+
+	var changes []*NICChange
+	if current.upstreamType != desired.upstreamType || current.switchID != desired.switchID {
+		if current.upstreamType != types.UpstreamNetworkTypes.None {
+			changes = append(changes, &NICChange{Index: index, Action: NICActionDisconnectFromSwitch, SwitchID: current.switchID})
+		}
+		switch desired.upstreamType {
+		case types.UpstreamNetworkTypes.Shared:
+			changes = append(changes, &NICChange{Index: index, Action: NICActionConnectToSharedSegment})
+		case types.UpstreamNetworkTypes.Switch:
+			changes = append(changes, &NICChange{Index: index, Action: NICActionConnectToSwitch, SwitchID: desired.switchID})
+		}
+	}
+	if current.packetFilterID != desired.packetFilterID {
+		if !current.packetFilterID.IsEmpty() {
+			changes = append(changes, &NICChange{Index: index, Action: NICActionDisconnectFromPacketFilter, PacketFilterID: current.packetFilterID})
+		}
+		if !desired.packetFilterID.IsEmpty() {
+			changes = append(changes, &NICChange{Index: index, Action: NICActionConnectToPacketFilter, PacketFilterID: desired.packetFilterID})
+		}
+	}
+	if current.displayIP != desired.displayIP {
+		changes = append(changes, &NICChange{Index: index, Action: NICActionUpdateDisplayIPAddress, DisplayIPAddress: desired.displayIP})
+	}
+	return changes
+}
+
+// planDisks reconcileDisksで行われる操作を返す
+func (b *Builder) planDisks(ctx context.Context, zone string, server *iaas.Server) []*DiskChange {
+	changes, reconnect := b.diskChanges(ctx, zone, server)
+	if !reconnect {
+		return changes
+	}
+
+	// 接続順を揃えるため全ディスクの切断/再接続が行われる
+	for i, disk := range server.Disks {
+		changes = append(changes, &DiskChange{Index: i, Action: DiskActionDisconnect, DiskID: disk.ID})
+	}
+	for i, diskReq := range b.DiskBuilders {
+		changes = append(changes, &DiskChange{Index: i, Action: DiskActionConnect, DiskID: diskReq.DiskID()})
+	}
+	return changes
+}
+
+// diskChanges DiskBuildersごとのディスクの作成/更新と、接続順を揃えるため全ディスクの切断/再接続が必要かを返す
+//
+// reconcileDisksとplanDisksの双方から利用される
+func (b *Builder) diskChanges(ctx context.Context, zone string, server *iaas.Server) ([]*DiskChange, bool) {
+	var changes []*DiskChange
+	reconnect := len(server.Disks) != len(b.DiskBuilders)
+
+	for i, diskReq := range b.DiskBuilders {
+		if diskReq.DiskID().IsEmpty() {
+			changes = append(changes, &DiskChange{Index: i, Action: DiskActionCreate})
+			reconnect = true
+			continue
+		}
+		if len(server.Disks) > i {
+			disk := server.Disks[i]
+			level := diskReq.UpdateLevel(ctx, zone, &iaas.Disk{
+				ID:              disk.ID,
+				Name:            disk.Name,
+				Availability:    disk.Availability,
+				Connection:      disk.Connection,
+				ConnectionOrder: disk.ConnectionOrder,
+				ReinstallCount:  disk.ReinstallCount,
+				SizeMB:          disk.SizeMB,
+				DiskPlanID:      disk.DiskPlanID,
+				Storage:         disk.Storage,
+			})
+			if level != service.UpdateLevelNone {
+				changes = append(changes, &DiskChange{Index: i, Action: DiskActionUpdate, DiskID: diskReq.DiskID(), UpdateLevel: level})
+			}
+			if disk.ID != diskReq.DiskID() {
+				reconnect = true
+			}
+		}
+	}
+	return changes, reconnect
+}
@@ -0,0 +1,159 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	disk "github.com/sacloud/iaas-service-go/disk/builder"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder_Plan(t *testing.T) {
+	client := func(server *iaas.Server) *APIClient {
+		return &APIClient{
+			Switch:       &dummySwitchReader{},
+			PacketFilter: &dummyPackerFilterReader{},
+			ServerPlan: &dummyPlanFinder{
+				plans: []*iaas.ServerPlan{{ID: 1}},
+			},
+			Server: &dummyCreateServerHandler{server: server},
+		}
+	}
+
+	t.Run("create", func(t *testing.T) {
+		builder := &Builder{
+			Name: "server",
+			NIC:  &SharedNICSetting{PacketFilterID: 2},
+			DiskBuilders: []disk.Builder{
+				&dummyDiskBuilder{},
+			},
+			Client: client(nil),
+		}
+
+		changeSet, err := builder.Plan(context.Background(), "tk1v")
+		require.NoError(t, err)
+		require.True(t, changeSet.Create)
+		require.True(t, changeSet.HasChanges())
+		require.Equal(t, []*NICChange{
+			{Index: 0, Action: NICActionCreate},
+			{Index: 0, Action: NICActionConnectToSharedSegment},
+			{Index: 0, Action: NICActionConnectToPacketFilter, PacketFilterID: 2},
+		}, changeSet.NICs)
+		require.Equal(t, []*DiskChange{
+			{Index: 0, Action: DiskActionCreate},
+		}, changeSet.Disks)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		builder := &Builder{
+			ServerID: 1,
+			Name:     "after",
+			CPU:      2,
+			MemoryGB: 1,
+			NIC:      &SharedNICSetting{PacketFilterID: 2},
+			AdditionalNICs: []AdditionalNICSettingHolder{
+				&ConnectedNICSetting{SwitchID: 3},
+			},
+			Client: client(&iaas.Server{
+				ID:              1,
+				Name:            "before",
+				CPU:             1,
+				MemoryMB:        1024,
+				Commitment:      types.Commitments.Standard,
+				InterfaceDriver: types.InterfaceDrivers.VirtIO,
+				Interfaces: []*iaas.InterfaceView{
+					{ID: 10, SwitchID: 5, SwitchScope: types.Scopes.Shared},
+				},
+			}),
+		}
+
+		changeSet, err := builder.Plan(context.Background(), "tk1v")
+		require.NoError(t, err)
+		require.False(t, changeSet.Create)
+		require.True(t, changeSet.IsNeedShutdown)
+		require.Equal(t, []*FieldChange{
+			{Field: "Name", Current: "before", Desired: "after"},
+		}, changeSet.Fields)
+		require.NotNil(t, changeSet.PlanChange)
+		require.Equal(t, 1, changeSet.PlanChange.Current.CPU)
+		require.Equal(t, 2, changeSet.PlanChange.Desired.CPU)
+		require.Equal(t, []*NICChange{
+			{Index: 0, Action: NICActionConnectToPacketFilter, PacketFilterID: 2},
+			{Index: 1, Action: NICActionCreate},
+			{Index: 1, Action: NICActionConnectToSwitch, SwitchID: 3},
+		}, changeSet.NICs)
+		require.Empty(t, changeSet.Disks)
+	})
+
+	t.Run("disks reconnected", func(t *testing.T) {
+		builder := &Builder{
+			ServerID: 1,
+			Name:     "server",
+			DiskBuilders: []disk.Builder{
+				&dummyDiskBuilder{diskID: 11},
+				&dummyDiskBuilder{diskID: 10},
+				&dummyDiskBuilder{},
+			},
+			Client: client(&iaas.Server{
+				ID:              1,
+				Name:            "server",
+				CPU:             1,
+				MemoryMB:        1024,
+				Commitment:      types.Commitments.Standard,
+				InterfaceDriver: types.InterfaceDrivers.VirtIO,
+				Disks: []*iaas.ServerConnectedDisk{
+					{ID: 10},
+					{ID: 11},
+				},
+			}),
+		}
+
+		changeSet, err := builder.Plan(context.Background(), "tk1v")
+		require.NoError(t, err)
+		// 接続順を揃えるため、変更のないディスクも含めて全ディスクが切断/再接続される
+		require.Equal(t, []*DiskChange{
+			{Index: 2, Action: DiskActionCreate},
+			{Index: 0, Action: DiskActionDisconnect, DiskID: 10},
+			{Index: 1, Action: DiskActionDisconnect, DiskID: 11},
+			{Index: 0, Action: DiskActionConnect, DiskID: 11},
+			{Index: 1, Action: DiskActionConnect, DiskID: 10},
+			{Index: 2, Action: DiskActionConnect},
+		}, changeSet.Disks)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		builder := &Builder{
+			ServerID: 1,
+			Name:     "server",
+			Client: client(&iaas.Server{
+				ID:              1,
+				Name:            "server",
+				CPU:             1,
+				MemoryMB:        1024,
+				Commitment:      types.Commitments.Standard,
+				InterfaceDriver: types.InterfaceDrivers.VirtIO,
+			}),
+		}
+
+		changeSet, err := builder.Plan(context.Background(), "tk1v")
+		require.NoError(t, err)
+		require.False(t, changeSet.HasChanges())
+		require.False(t, changeSet.IsNeedShutdown)
+	})
+}
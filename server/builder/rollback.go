@@ -0,0 +1,80 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/sacloud/iaas-api-go/helper/power"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/progress"
+)
+
+// ResourceDeleter ロールバック時にリソースを削除するためのインターフェース
+//
+// APIClient.Disk/APIClient.Serverがこのインターフェースを実装していない場合、ロールバック時の削除はエラーとなる
+type ResourceDeleter interface {
+	Delete(ctx context.Context, zone string, id types.ID) error
+}
+
+// rollback Build中に作成したリソースを削除する
+//
+// ディスク、サーバの順に削除する。NICはサーバの削除に合わせて削除される。
+// 既存ディスクを接続した場合はサーバからの切断のみ行い、ディスクは削除しない。
+// 個々の処理に失敗した場合も残りのリソースの削除を継続し、発生したエラーをまとめて返す。
+func (b *Builder) rollback(ctx context.Context, zone string, result *BuildResult) error {
+	var errs []error
+
+	server, err := b.Client.Server.Read(ctx, zone, result.ServerID)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("reading server[%s] failed: %w", result.ServerID, err))
+	} else if server.InstanceStatus.IsUp() {
+		if err := power.ShutdownServer(ctx, b.Client.Server, zone, server.ID, true); err != nil {
+			errs = append(errs, fmt.Errorf("shutting down server[%s] failed: %w", server.ID, err))
+		}
+	}
+
+	for _, diskID := range result.DiskIDs {
+		if err := b.Client.Disk.DisconnectFromServer(ctx, zone, diskID); err != nil {
+			errs = append(errs, fmt.Errorf("disconnecting disk[%s] failed: %w", diskID, err))
+			continue
+		}
+		// 既存ディスクは切断のみ行う
+		if slices.Contains(result.ConnectedDiskIDs, diskID) {
+			continue
+		}
+		progress.Emit(ctx, &progress.Event{Type: progress.EventDeleting, Resource: "Disk", Zone: zone, ID: diskID, Message: "rollback"})
+		if err := deleteResource(ctx, b.Client.Disk, zone, diskID); err != nil {
+			errs = append(errs, fmt.Errorf("deleting disk[%s] failed: %w", diskID, err))
+		}
+	}
+
+	progress.Emit(ctx, &progress.Event{Type: progress.EventDeleting, Resource: "Server", Zone: zone, ID: result.ServerID, Message: "rollback"})
+	if err := deleteResource(ctx, b.Client.Server, zone, result.ServerID); err != nil {
+		errs = append(errs, fmt.Errorf("deleting server[%s] failed: %w", result.ServerID, err))
+	}
+	return errors.Join(errs...)
+}
+
+func deleteResource(ctx context.Context, client interface{}, zone string, id types.ID) error {
+	deleter, ok := client.(ResourceDeleter)
+	if !ok {
+		return fmt.Errorf("%T does not implement ResourceDeleter", client)
+	}
+	return deleter.Delete(ctx, zone, id)
+}
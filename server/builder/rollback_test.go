@@ -0,0 +1,310 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	disk "github.com/sacloud/iaas-service-go/disk/builder"
+	"github.com/sacloud/packages-go/size"
+	"github.com/stretchr/testify/require"
+)
+
+type rollbackRecorder struct {
+	calls     []string
+	deleteErr error
+}
+
+func (r *rollbackRecorder) record(format string, args ...interface{}) {
+	r.calls = append(r.calls, fmt.Sprintf(format, args...))
+}
+
+type rollbackDiskHandler struct {
+	*rollbackRecorder
+}
+
+func (d *rollbackDiskHandler) ConnectToServer(ctx context.Context, zone string, id types.ID, serverID types.ID) error {
+	return nil
+}
+
+func (d *rollbackDiskHandler) DisconnectFromServer(ctx context.Context, zone string, id types.ID) error {
+	d.record("disconnect disk %s", id)
+	return nil
+}
+
+func (d *rollbackDiskHandler) Delete(ctx context.Context, zone string, id types.ID) error {
+	d.record("delete disk %s", id)
+	return d.deleteErr
+}
+
+type rollbackServerHandler struct {
+	dummyCreateServerHandler
+	*rollbackRecorder
+	readErr error
+}
+
+func (d *rollbackServerHandler) Read(ctx context.Context, zone string, id types.ID) (*iaas.Server, error) {
+	if d.readErr != nil {
+		return nil, d.readErr
+	}
+	return d.dummyCreateServerHandler.Read(ctx, zone, id)
+}
+
+func (d *rollbackServerHandler) Delete(ctx context.Context, zone string, id types.ID) error {
+	d.record("delete server %s", id)
+	return nil
+}
+
+func newRollbackTestBuilder(recorder *rollbackRecorder, rollback bool, diskBuilders ...disk.Builder) *Builder {
+	return &Builder{
+		DiskBuilders: diskBuilders,
+		Client: &APIClient{
+			Disk:         &rollbackDiskHandler{rollbackRecorder: recorder},
+			Interface:    &dummyInterfaceHandler{},
+			Switch:       &dummySwitchReader{},
+			PacketFilter: &dummyPackerFilterReader{},
+			ServerPlan: &dummyPlanFinder{
+				plans: []*iaas.ServerPlan{{ID: 1}},
+			},
+			Server: &rollbackServerHandler{
+				dummyCreateServerHandler: dummyCreateServerHandler{
+					server: &iaas.Server{
+						ID:         1,
+						Interfaces: []*iaas.InterfaceView{{ID: 4}},
+					},
+				},
+				rollbackRecorder: recorder,
+			},
+		},
+		RollbackOnFailure: rollback,
+	}
+}
+
+func TestBuilder_Build_Rollback(t *testing.T) {
+	buildErr := errors.New("dummy")
+
+	newBuilder := func(recorder *rollbackRecorder, rollback bool) *Builder {
+		return newRollbackTestBuilder(recorder, rollback,
+			&dummyDiskBuilder{result: &disk.BuildResult{DiskID: 2}},
+			&dummyDiskBuilder{result: &disk.BuildResult{DiskID: 3}},
+			&buildErrorDiskBuilder{err: buildErr},
+		)
+	}
+
+	t.Run("without rollback", func(t *testing.T) {
+		recorder := &rollbackRecorder{}
+		result, err := newBuilder(recorder, false).Build(context.Background(), "tk1v")
+		require.Equal(t, buildErr, err)
+		require.Equal(t, &BuildResult{ServerID: 1, DiskIDs: []types.ID{2, 3}}, result)
+		require.Empty(t, recorder.calls)
+	})
+
+	t.Run("with rollback", func(t *testing.T) {
+		recorder := &rollbackRecorder{}
+		result, err := newBuilder(recorder, true).Build(context.Background(), "tk1v")
+		require.Equal(t, buildErr, err)
+		require.Nil(t, result)
+		require.Equal(t, []string{
+			"disconnect disk 2",
+			"delete disk 2",
+			"disconnect disk 3",
+			"delete disk 3",
+			"delete server 1",
+		}, recorder.calls)
+	})
+
+	t.Run("rollback returns error", func(t *testing.T) {
+		deleteErr := errors.New("delete failed")
+		recorder := &rollbackRecorder{deleteErr: deleteErr}
+		result, err := newBuilder(recorder, true).Build(context.Background(), "tk1v")
+		require.ErrorIs(t, err, buildErr)
+		require.ErrorIs(t, err, deleteErr)
+		require.Equal(t, &BuildResult{ServerID: 1, DiskIDs: []types.ID{2, 3}}, result)
+		require.Equal(t, []string{
+			"disconnect disk 2",
+			"delete disk 2",
+			"disconnect disk 3",
+			"delete disk 3",
+			"delete server 1",
+		}, recorder.calls)
+	})
+
+	t.Run("rollback continues when reading server failed", func(t *testing.T) {
+		readErr := errors.New("read failed")
+		recorder := &rollbackRecorder{}
+		builder := newBuilder(recorder, true)
+		builder.Client.Server.(*rollbackServerHandler).readErr = readErr
+
+		result, err := builder.Build(context.Background(), "tk1v")
+		require.ErrorIs(t, err, buildErr)
+		require.ErrorIs(t, err, readErr)
+		require.Equal(t, &BuildResult{ServerID: 1, DiskIDs: []types.ID{2, 3}}, result)
+		require.Equal(t, []string{
+			"disconnect disk 2",
+			"delete disk 2",
+			"disconnect disk 3",
+			"delete disk 3",
+			"delete server 1",
+		}, recorder.calls)
+	})
+
+	t.Run("disk handler without Delete", func(t *testing.T) {
+		recorder := &rollbackRecorder{}
+		builder := newBuilder(recorder, true)
+		builder.Client.Disk = &rollbackDiskConnector{rollbackRecorder: recorder}
+
+		result, err := builder.Build(context.Background(), "tk1v")
+		require.ErrorIs(t, err, buildErr)
+		require.ErrorContains(t, err, "does not implement ResourceDeleter")
+		require.Equal(t, &BuildResult{ServerID: 1, DiskIDs: []types.ID{2, 3}}, result)
+		require.Equal(t, []string{
+			"disconnect disk 2",
+			"disconnect disk 3",
+			"delete server 1",
+		}, recorder.calls)
+	})
+}
+
+// rollbackDiskConnector ResourceDeleterを実装しないDiskHandler
+type rollbackDiskConnector struct {
+	*rollbackRecorder
+}
+
+func (d *rollbackDiskConnector) ConnectToServer(ctx context.Context, zone string, id types.ID, serverID types.ID) error {
+	return nil
+}
+
+func (d *rollbackDiskConnector) DisconnectFromServer(ctx context.Context, zone string, id types.ID) error {
+	d.record("disconnect disk %s", id)
+	return nil
+}
+
+// buildErrorDiskBuilder Validateは成功しBuild時にエラーを返すdisk.Builder
+type buildErrorDiskBuilder struct {
+	dummyDiskBuilder
+	err error
+}
+
+func (d *buildErrorDiskBuilder) Build(ctx context.Context, zone string, serverID types.ID) (*disk.BuildResult, error) {
+	return nil, d.err
+}
+
+// rollbackCreateDiskHandler disk.Builderが利用するディスク操作のスタブ
+type rollbackCreateDiskHandler struct {
+	disk.CreateDiskHandler
+	created   *iaas.Disk
+	createErr error
+	readErr   error
+}
+
+func (d *rollbackCreateDiskHandler) Create(ctx context.Context, zone string, createParam *iaas.DiskCreateRequest, distantFrom []types.ID, kmsKeyID types.ID) (*iaas.Disk, error) {
+	return d.created, d.createErr
+}
+
+func (d *rollbackCreateDiskHandler) Read(ctx context.Context, zone string, id types.ID) (*iaas.Disk, error) {
+	if d.readErr != nil {
+		return nil, d.readErr
+	}
+	return &iaas.Disk{ID: id, Availability: types.Availabilities.Available}, nil
+}
+
+func (d *rollbackCreateDiskHandler) ConnectToServer(ctx context.Context, zone string, id types.ID, serverID types.ID) error {
+	return nil
+}
+
+type rollbackDiskPlanReader struct{}
+
+func (d *rollbackDiskPlanReader) Read(ctx context.Context, zone string, id types.ID) (*iaas.DiskPlan, error) {
+	return &iaas.DiskPlan{
+		ID: id,
+		Size: []*iaas.DiskPlanSizeInfo{
+			{Availability: types.Availabilities.Available, SizeMB: 20 * size.GiB},
+		},
+	}, nil
+}
+
+func TestBuilder_Build_RollbackDisks(t *testing.T) {
+	buildErr := errors.New("dummy")
+
+	t.Run("connected disk is disconnected but not deleted", func(t *testing.T) {
+		recorder := &rollbackRecorder{}
+		connected := &disk.ConnectedDiskBuilder{
+			ID:     5,
+			Client: &disk.APIClient{Disk: &rollbackCreateDiskHandler{}},
+		}
+		builder := newRollbackTestBuilder(recorder, true,
+			&dummyDiskBuilder{result: &disk.BuildResult{DiskID: 2}},
+			connected,
+			&buildErrorDiskBuilder{err: buildErr},
+		)
+
+		result, err := builder.Build(context.Background(), "tk1v")
+		require.Equal(t, buildErr, err)
+		require.Nil(t, result)
+		require.Equal(t, []string{
+			"disconnect disk 2",
+			"delete disk 2",
+			"disconnect disk 5",
+			"delete server 1",
+		}, recorder.calls)
+	})
+
+	cases := []struct {
+		msg     string
+		handler *rollbackCreateDiskHandler
+	}{
+		{
+			msg:     "disk creation failed",
+			handler: &rollbackCreateDiskHandler{created: &iaas.Disk{ID: 6}, createErr: buildErr},
+		},
+		{
+			msg:     "waiting for disk failed",
+			handler: &rollbackCreateDiskHandler{created: &iaas.Disk{ID: 6}, readErr: buildErr},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.msg, func(t *testing.T) {
+			recorder := &rollbackRecorder{}
+			blank := &disk.BlankBuilder{
+				Name:   "blank",
+				SizeGB: 20,
+				PlanID: types.DiskPlans.SSD,
+				Client: &disk.APIClient{
+					Disk:     tc.handler,
+					DiskPlan: &rollbackDiskPlanReader{},
+				},
+			}
+
+			result, err := newRollbackTestBuilder(recorder, false, blank).Build(context.Background(), "tk1v")
+			require.ErrorIs(t, err, buildErr)
+			require.Equal(t, &BuildResult{ServerID: 1, DiskIDs: []types.ID{6}}, result)
+
+			recorder = &rollbackRecorder{}
+			result, err = newRollbackTestBuilder(recorder, true, blank).Build(context.Background(), "tk1v")
+			require.ErrorIs(t, err, buildErr)
+			require.Nil(t, result)
+			require.Equal(t, []string{
+				"disconnect disk 6",
+				"delete disk 6",
+				"delete server 1",
+			}, recorder.calls)
+		})
+	}
+}
@@ -44,6 +44,8 @@ type CreateRequest struct {
 	NetworkInterfaces []*NetworkInterface
 	Disks             []*diskService.ApplyRequest
 	NoWait            bool
+
+	RollbackOnFailure bool // trueの場合、作成に失敗した際に作成済みのディスク/NIC/サーバを削除する
 }
 
 func (req *CreateRequest) Validate() error {
@@ -72,5 +74,6 @@ func (req *CreateRequest) ApplyRequest() *ApplyRequest {
 		NetworkInterfaces: req.NetworkInterfaces,
 		Disks:             req.Disks,
 		NoWait:            req.NoWait,
+		RollbackOnFailure: req.RollbackOnFailure,
 	}
 }
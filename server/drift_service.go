@@ -0,0 +1,62 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-service-go/drift"
+)
+
+// driftIgnoreFields サーバのDriftで比較しないフィールド
+var driftIgnoreFields = []string{
+	"Zone",
+	"ID",
+	// 作成時の起動、Apply時の待機/強制シャットダウン/ロールバックの指定であり、リソースの状態ではないため
+	"BootAfterCreate",
+	"NoWait",
+	"ForceShutdown",
+	"RollbackOnFailure",
+	// ディスクの識別子や接続先はサーバ側で決まるため
+	"Disks.Zone",
+	"Disks.ID",
+	"Disks.ServerID",
+	// ディスクの作成時のみ指定可能で、現在のリソースからは復元されないため
+	"Disks.EncryptionAlgorithm",
+	"Disks.KMSKeyID",
+	"Disks.DedicatedStorageContractID",
+	"Disks.SourceDiskID",
+	"Disks.SourceArchiveID",
+	"Disks.DistantFrom",
+	"Disks.OSType",
+	"Disks.EditParameter",
+	"Disks.NoWait",
+}
+
+// Drift 現在のリソースとDesiredをフィールドごとに比較し差分を返す
+func (s *Service) Drift(req *DriftRequest) (*drift.Result, error) {
+	return s.DriftWithContext(context.Background(), req)
+}
+
+// DriftWithContext 現在のリソースとDesiredをフィールドごとに比較し差分を返す
+func (s *Service) DriftWithContext(ctx context.Context, req *DriftRequest) (*drift.Result, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	return drift.Detect(ctx, req.Zone, req.ID, req.Desired, func(ctx context.Context) (*ApplyRequest, error) {
+		return (&UpdateRequest{Zone: req.Zone, ID: req.ID}).ApplyRequest(ctx, s.caller)
+	}, driftIgnoreFields...)
+}
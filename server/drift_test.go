@@ -0,0 +1,44 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+
+	diskService "github.com/sacloud/iaas-service-go/disk"
+	"github.com/sacloud/iaas-service-go/drift"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerService_driftIgnoreFields(t *testing.T) {
+	current := &ApplyRequest{
+		Disks: []*diskService.ApplyRequest{
+			{Zone: "tk1a", ID: 201, ServerID: 101, SizeGB: 20},
+		},
+	}
+	// ディスクの識別子や作成時のみの指定は比較しない
+	desired := &ApplyRequest{
+		Disks: []*diskService.ApplyRequest{
+			{SourceArchiveID: 202, SizeGB: 20, NoWait: true},
+		},
+	}
+	require.Empty(t, drift.Compare(current, desired, driftIgnoreFields...))
+
+	// ConfidentialVMは比較対象
+	desired.ConfidentialVM = true
+	diffs := drift.Compare(current, desired, driftIgnoreFields...)
+	require.Len(t, diffs, 1)
+	require.Equal(t, "ConfidentialVM", diffs[0].Path)
+}
@@ -0,0 +1,86 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/testutil"
+	"github.com/sacloud/iaas-api-go/types"
+	diskService "github.com/sacloud/iaas-service-go/disk"
+	"github.com/sacloud/iaas-service-go/serviceutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerService_ExportApplyRoundTrip(t *testing.T) {
+	if testutil.IsAccTest() {
+		t.Skip("This test runs only without TESTACC=1")
+	}
+	ctx := context.Background()
+	zone := testutil.TestZone()
+	name := testutil.ResourceName("service-export-server")
+	caller := testutil.SingletonAPICaller()
+
+	svc := New(caller)
+	server, err := svc.CreateWithContext(ctx, &CreateRequest{
+		Zone:            zone,
+		Name:            name,
+		Description:     "desc",
+		Tags:            types.Tags{"tag1", "tag2"},
+		CPU:             1,
+		MemoryGB:        1,
+		CPUModel:        "uncategorized",
+		Commitment:      types.Commitments.Standard,
+		Generation:      types.PlanGenerations.G100,
+		InterfaceDriver: types.InterfaceDrivers.VirtIO,
+		ConfidentialVM:  true,
+		NetworkInterfaces: []*NetworkInterface{
+			{Upstream: "shared"},
+		},
+		Disks: []*diskService.ApplyRequest{
+			{
+				Zone:       zone,
+				Name:       name,
+				DiskPlanID: types.DiskPlans.SSD,
+				Connection: types.DiskConnections.VirtIO,
+				SizeGB:     20,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		iaas.NewServerOp(caller).Delete(ctx, zone, server.ID) //nolint
+		for _, disk := range server.Disks {
+			iaas.NewDiskOp(caller).Delete(ctx, zone, disk.ID) //nolint
+		}
+	}()
+
+	exported, err := svc.ExportWithContext(ctx, &ExportRequest{Zone: zone, ID: server.ID})
+	require.NoError(t, err)
+
+	applyRequest := &ApplyRequest{}
+	require.NoError(t, serviceutil.UnmarshalRequest(exported, serviceutil.FormatJSON, applyRequest))
+	require.True(t, applyRequest.ConfidentialVM)
+
+	applied, err := svc.ApplyWithContext(ctx, applyRequest)
+	require.NoError(t, err)
+	require.Equal(t, server.ID, applied.ID)
+	require.True(t, applied.ConfidentialVM)
+}
@@ -14,7 +14,10 @@
 
 package server
 
-import "github.com/sacloud/iaas-api-go"
+import (
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
 
 // Service provides a high-level API of for Server
 type Service struct {
@@ -25,3 +28,13 @@ type Service struct {
 func New(caller iaas.APICaller) *Service {
 	return &Service{caller: caller}
 }
+
+var (
+	_ service.Creator[*CreateRequest, *iaas.Server]    = (*Service)(nil)
+	_ service.Reader[*ReadRequest, *iaas.Server]       = (*Service)(nil)
+	_ service.Updater[*UpdateRequest, *iaas.Server]    = (*Service)(nil)
+	_ service.Deleter[*DeleteRequest]                  = (*Service)(nil)
+	_ service.Finder[*FindRequest, *iaas.Server]       = (*Service)(nil)
+	_ service.FindIterator[*FindRequest, *iaas.Server] = (*Service)(nil)
+	_ service.Applier[*ApplyRequest, *iaas.Server]     = (*Service)(nil)
+)
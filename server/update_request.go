@@ -132,6 +132,7 @@ func (req *UpdateRequest) applyRequestFromResource(ctx context.Context, caller i
 		InterfaceDriver:   current.InterfaceDriver,
 		CDROMID:           current.CDROMID,
 		PrivateHostID:     current.PrivateHostID,
+		ConfidentialVM:    current.ConfidentialVM,
 		NetworkInterfaces: nics,
 		Disks:             disks,
 	}
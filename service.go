@@ -0,0 +1,62 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iaas
+
+import (
+	"context"
+	"iter"
+)
+
+// Creator リソースの作成を行うサービス
+type Creator[Req any, T any] interface {
+	Create(req Req) (T, error)
+	CreateWithContext(ctx context.Context, req Req) (T, error)
+}
+
+// Reader リソースの参照を行うサービス
+type Reader[Req any, T any] interface {
+	Read(req Req) (T, error)
+	ReadWithContext(ctx context.Context, req Req) (T, error)
+}
+
+// Updater リソースの更新を行うサービス
+type Updater[Req any, T any] interface {
+	Update(req Req) (T, error)
+	UpdateWithContext(ctx context.Context, req Req) (T, error)
+}
+
+// Deleter リソースの削除を行うサービス
+type Deleter[Req any] interface {
+	Delete(req Req) error
+	DeleteWithContext(ctx context.Context, req Req) error
+}
+
+// Finder リソースの検索を行うサービス
+type Finder[Req any, T any] interface {
+	Find(req Req) ([]T, error)
+	FindWithContext(ctx context.Context, req Req) ([]T, error)
+}
+
+// FindIterator リソースの検索結果をページ単位で遅延取得しながら1件ずつ返すサービス
+type FindIterator[Req any, T any] interface {
+	FindIter(req Req) iter.Seq2[T, error]
+	FindIterWithContext(ctx context.Context, req Req) iter.Seq2[T, error]
+}
+
+// Applier リソースの作成または更新を行うサービス
+type Applier[Req any, T any] interface {
+	Apply(req Req) (T, error)
+	ApplyWithContext(ctx context.Context, req Req) (T, error)
+}
@@ -14,7 +14,10 @@
 
 package serviceclass
 
-import "github.com/sacloud/iaas-api-go"
+import (
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
 
 // Service provides a high-level API of for ServiceClass
 type Service struct {
@@ -25,3 +28,8 @@ type Service struct {
 func New(caller iaas.APICaller) *Service {
 	return &Service{caller: caller}
 }
+
+var (
+	_ service.Finder[*FindRequest, *iaas.ServiceClass]       = (*Service)(nil)
+	_ service.FindIterator[*FindRequest, *iaas.ServiceClass] = (*Service)(nil)
+)
@@ -0,0 +1,55 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceutil
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+)
+
+const (
+	// FormatJSON JSON形式
+	FormatJSON = "json"
+	// FormatYAML YAML形式
+	FormatYAML = "yaml"
+)
+
+// MarshalRequest リクエストを指定の形式で出力する、formatが空の場合はJSON形式
+//
+// YAMLへの変換はJSONを経由するため、各型のMarshalJSON/UnmarshalJSONが利用される
+func MarshalRequest(v interface{}, format string) ([]byte, error) {
+	switch format {
+	case "", FormatJSON:
+		return json.MarshalIndent(v, "", "  ")
+	case FormatYAML:
+		return yaml.Marshal(v)
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// UnmarshalRequest MarshalRequestで出力したデータをリクエストに読み込む、formatが空の場合はJSON形式
+func UnmarshalRequest(data []byte, format string, v interface{}) error {
+	switch format {
+	case "", FormatJSON:
+		return json.Unmarshal(data, v)
+	case FormatYAML:
+		return yaml.Unmarshal(data, v)
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+}
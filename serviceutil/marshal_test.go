@@ -0,0 +1,81 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type marshalTestRequest struct {
+	Name     string
+	Tags     []string
+	Settings *marshalTestSetting
+}
+
+type marshalTestSetting struct {
+	Port int
+}
+
+func TestMarshalRequest(t *testing.T) {
+	req := &marshalTestRequest{
+		Name:     "example",
+		Tags:     []string{"tag1", "tag2"},
+		Settings: &marshalTestSetting{Port: 80},
+	}
+
+	cases := []struct {
+		format string
+		expect string
+	}{
+		{
+			format: "",
+			expect: `{
+  "Name": "example",
+  "Tags": [
+    "tag1",
+    "tag2"
+  ],
+  "Settings": {
+    "Port": 80
+  }
+}`,
+		},
+		{
+			format: FormatYAML,
+			expect: `Name: example
+Settings:
+  Port: 80
+Tags:
+- tag1
+- tag2
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		data, err := MarshalRequest(req, tc.format)
+		require.NoError(t, err)
+		require.Equal(t, tc.expect, string(data))
+
+		var got marshalTestRequest
+		require.NoError(t, UnmarshalRequest(data, tc.format, &got))
+		require.Equal(t, req, &got)
+	}
+
+	_, err := MarshalRequest(req, "xml")
+	require.Error(t, err)
+}
@@ -0,0 +1,77 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// DefaultConflictRetryCount UpdateWithMutatorでコンフリクト発生時にリトライする回数のデフォルト値
+var DefaultConflictRetryCount = 3
+
+// IsConflictError errがSettingsHashの不一致などによるコンフリクト(409 Conflict)を示すエラーか判定する
+func IsConflictError(err error) bool {
+	var apiErr interface{ ResponseCode() int }
+	if errors.As(err, &apiErr) {
+		return apiErr.ResponseCode() == http.StatusConflict
+	}
+	return false
+}
+
+// UpdateWithMutator readで取得した現在のリソースにmutateで変更を加え、updateで更新する
+//
+// updateはreadで取得したSettingsHashなどを用いて楽観的排他制御を行うことを想定している。
+// updateがコンフリクトエラーを返した場合はreadからやり直し、最大maxRetries回までリトライする。
+// maxRetriesが0以下の場合はDefaultConflictRetryCountを利用する。
+func UpdateWithMutator[T any, R any](
+	ctx context.Context,
+	maxRetries int,
+	read func(ctx context.Context) (T, error),
+	mutate func(current T) error,
+	update func(ctx context.Context, mutated T) (R, error),
+) (R, error) {
+	if maxRetries <= 0 {
+		maxRetries = DefaultConflictRetryCount
+	}
+
+	var zero R
+	for attempt := 0; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+
+		current, err := read(ctx)
+		if err != nil {
+			return zero, err
+		}
+		if err := mutate(current); err != nil {
+			return zero, err
+		}
+
+		updated, err := update(ctx, current)
+		if err == nil {
+			return updated, nil
+		}
+		if !IsConflictError(err) {
+			return zero, err
+		}
+		if attempt >= maxRetries {
+			return zero, fmt.Errorf("update conflicted %d times: %w", attempt+1, err)
+		}
+	}
+}
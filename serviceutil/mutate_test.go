@@ -0,0 +1,115 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type dummyAPIError struct {
+	code int
+}
+
+func (e *dummyAPIError) Error() string {
+	return fmt.Sprintf("dummy api error: %d", e.code)
+}
+
+func (e *dummyAPIError) ResponseCode() int {
+	return e.code
+}
+
+type dummyResource struct {
+	Value        int
+	SettingsHash string
+}
+
+type dummyStore struct {
+	value    int
+	hash     int
+	conflict int // 指定回数だけupdate直前に他者による更新を発生させる
+}
+
+func (s *dummyStore) read(_ context.Context) (*dummyResource, error) {
+	return &dummyResource{Value: s.value, SettingsHash: fmt.Sprintf("%d", s.hash)}, nil
+}
+
+func (s *dummyStore) update(_ context.Context, r *dummyResource) (*dummyResource, error) {
+	if s.conflict > 0 {
+		s.conflict--
+		s.value += 100
+		s.hash++
+	}
+	if r.SettingsHash != fmt.Sprintf("%d", s.hash) {
+		return nil, &dummyAPIError{code: http.StatusConflict}
+	}
+	s.value = r.Value
+	s.hash++
+	return s.read(context.Background())
+}
+
+func TestUpdateWithMutator(t *testing.T) {
+	increment := func(r *dummyResource) error {
+		r.Value++
+		return nil
+	}
+
+	t.Run("without conflict", func(t *testing.T) {
+		store := &dummyStore{}
+		updated, err := UpdateWithMutator(context.Background(), 0, store.read, increment, store.update)
+		require.NoError(t, err)
+		require.Equal(t, 1, updated.Value)
+	})
+
+	t.Run("retry on conflict", func(t *testing.T) {
+		store := &dummyStore{conflict: 2}
+		updated, err := UpdateWithMutator(context.Background(), 2, store.read, increment, store.update)
+		require.NoError(t, err)
+		require.Equal(t, 201, updated.Value)
+	})
+
+	t.Run("retry exceeded", func(t *testing.T) {
+		store := &dummyStore{conflict: 3}
+		_, err := UpdateWithMutator(context.Background(), 2, store.read, increment, store.update)
+		require.Error(t, err)
+		require.True(t, IsConflictError(err))
+		require.Equal(t, 300, store.value)
+	})
+
+	t.Run("mutator error", func(t *testing.T) {
+		store := &dummyStore{}
+		_, err := UpdateWithMutator(context.Background(), 0, store.read, func(*dummyResource) error {
+			return errors.New("dummy")
+		}, store.update)
+		require.EqualError(t, err, "dummy")
+		require.Equal(t, 0, store.hash)
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		calls := 0
+		_, err := UpdateWithMutator(context.Background(), 0, (&dummyStore{}).read, increment,
+			func(context.Context, *dummyResource) (*dummyResource, error) {
+				calls++
+				return nil, &dummyAPIError{code: http.StatusInternalServerError}
+			})
+		require.Error(t, err)
+		require.Equal(t, 1, calls)
+	})
+}
@@ -0,0 +1,61 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceutil
+
+import (
+	"context"
+	"iter"
+)
+
+// DefaultPageSize Paginateで1回の検索で取得する件数のデフォルト値
+var DefaultPageSize = 100
+
+// PageFunc from件目からcount件を取得する関数
+type PageFunc[T any] func(ctx context.Context, from, count int) ([]T, error)
+
+// Paginate findを用いてページ単位で遅延取得しながら1件ずつ返すイテレータを作成する
+//
+// pageSizeが0以下の場合はDefaultPageSizeを利用する。
+// 取得件数がpageSize未満となった時点で終了する。
+// 取得時のエラーやctxのキャンセルは2番目の値として返し、イテレーションを終了する。
+func Paginate[T any](ctx context.Context, from, pageSize int, find PageFunc[T]) iter.Seq2[T, error] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return func(yield func(T, error) bool) {
+		var zero T
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			page, err := find(ctx, from, pageSize)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, v := range page {
+				if !yield(v, nil) {
+					return
+				}
+			}
+			if len(page) < pageSize {
+				return
+			}
+			from += len(page)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginate(t *testing.T) {
+	var items []int
+	for i := 0; i < 25; i++ {
+		items = append(items, i)
+	}
+
+	var calls [][2]int
+	find := func(ctx context.Context, from, count int) ([]int, error) {
+		calls = append(calls, [2]int{from, count})
+		if from >= len(items) {
+			return nil, nil
+		}
+		return items[from:min(from+count, len(items))], nil
+	}
+
+	t.Run("all", func(t *testing.T) {
+		calls = nil
+		var got []int
+		for v, err := range Paginate(context.Background(), 0, 10, find) {
+			require.NoError(t, err)
+			got = append(got, v)
+		}
+		require.Equal(t, items, got)
+		require.Equal(t, [][2]int{{0, 10}, {10, 10}, {20, 10}}, calls)
+	})
+
+	t.Run("with from and default page size", func(t *testing.T) {
+		calls = nil
+		var got []int
+		for v, err := range Paginate(context.Background(), 20, 0, find) {
+			require.NoError(t, err)
+			got = append(got, v)
+		}
+		require.Equal(t, items[20:], got)
+		require.Equal(t, [][2]int{{20, DefaultPageSize}}, calls)
+	})
+
+	t.Run("break", func(t *testing.T) {
+		calls = nil
+		var got []int
+		for v := range Paginate(context.Background(), 0, 10, find) {
+			got = append(got, v)
+			if v == 12 {
+				break
+			}
+		}
+		require.Len(t, got, 13)
+		require.Equal(t, [][2]int{{0, 10}, {10, 10}}, calls)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var errs []error
+		for _, err := range Paginate(context.Background(), 0, 10, func(ctx context.Context, from, count int) ([]int, error) {
+			return nil, errors.New("dummy")
+		}) {
+			errs = append(errs, err)
+		}
+		require.Len(t, errs, 1)
+		require.EqualError(t, errs[0], "dummy")
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		calls = nil
+		var got []int
+		var lastErr error
+		for v, err := range Paginate(ctx, 0, 10, find) {
+			if err != nil {
+				lastErr = err
+				break
+			}
+			got = append(got, v)
+			if v == 9 {
+				cancel()
+			}
+		}
+		require.Len(t, got, 10)
+		require.ErrorIs(t, lastErr, context.Canceled)
+		require.Len(t, calls, 1)
+	})
+}
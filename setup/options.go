@@ -57,6 +57,19 @@ type Options struct {
 	DeleteRetryInterval time.Duration
 	// sacloud.StateWaiterによるステート待ちの間隔
 	PollingInterval time.Duration
+
+	// RetryPolicy リソースの削除&再作成のリトライポリシー
+	//
+	// 省略時はRetryCountを元にしたFixedIntervalPolicyが利用される
+	RetryPolicy RetryPolicy
+	// ProvisioningRetryPolicy プロビジョニングAPI呼び出しのリトライポリシー
+	//
+	// 省略時はProvisioningRetryCount/ProvisioningRetryIntervalを元にしたFixedIntervalPolicyが利用される
+	ProvisioningRetryPolicy RetryPolicy
+	// DeleteRetryPolicy 削除API呼び出しのリトライポリシー
+	//
+	// 省略時はDeleteRetryCount/DeleteRetryIntervalを元にしたFixedIntervalPolicyが利用される
+	DeleteRetryPolicy RetryPolicy
 }
 
 func (o *Options) Init() {
@@ -82,3 +95,33 @@ func (o *Options) Init() {
 		o.PollingInterval = DefaultPollingInterval
 	}
 }
+
+func (o *Options) retryPolicy() RetryPolicy {
+	if o.RetryPolicy != nil {
+		return o.RetryPolicy
+	}
+	// RetryCountは初回を含まないリトライ回数のため、最大試行回数は+1する
+	return &FixedIntervalPolicy{
+		MaxAttempts: o.RetryCount + 1,
+	}
+}
+
+func (o *Options) provisioningRetryPolicy() RetryPolicy {
+	if o.ProvisioningRetryPolicy != nil {
+		return o.ProvisioningRetryPolicy
+	}
+	return &FixedIntervalPolicy{
+		MaxAttempts: o.ProvisioningRetryCount,
+		Interval:    o.ProvisioningRetryInterval,
+	}
+}
+
+func (o *Options) deleteRetryPolicy() RetryPolicy {
+	if o.DeleteRetryPolicy != nil {
+		return o.DeleteRetryPolicy
+	}
+	return &FixedIntervalPolicy{
+		MaxAttempts: o.DeleteRetryCount,
+		Interval:    o.DeleteRetryInterval,
+	}
+}
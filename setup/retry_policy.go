@@ -0,0 +1,164 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package setup
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy リトライ可否と次の試行までの待ち時間を決定するポリシー
+type RetryPolicy interface {
+	// NextDelay attempt回目(1始まり)の試行がerrで失敗した際に、次の試行までの待ち時間を返す
+	//
+	// elapsedは最初の試行開始からの経過時間。リトライしない場合は2番目の戻り値にfalseを返す。
+	NextDelay(attempt int, elapsed time.Duration, err error) (time.Duration, bool)
+}
+
+// ErrorClassifier エラーがリトライ可能か判定する関数
+type ErrorClassifier func(err error) bool
+
+// DefaultErrorClassifier コンテキストのキャンセル/タイムアウト以外のエラーをリトライ可能と判定する
+func DefaultErrorClassifier(err error) bool {
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// FixedIntervalPolicy 一定間隔でリトライを行うRetryPolicy
+type FixedIntervalPolicy struct {
+	// MaxAttempts 最大試行回数(初回を含む)、0以下の場合は無制限
+	MaxAttempts int
+	// Interval リトライ間隔
+	Interval time.Duration
+	// Classifier リトライ可能なエラーの判定、省略時はDefaultErrorClassifier
+	Classifier ErrorClassifier
+}
+
+// NextDelay RetryPolicyの実装
+func (p *FixedIntervalPolicy) NextDelay(attempt int, _ time.Duration, err error) (time.Duration, bool) {
+	if !classify(p.Classifier, err) {
+		return 0, false
+	}
+	if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+		return 0, false
+	}
+	return p.Interval, true
+}
+
+// ExponentialBackoffPolicy 指数バックオフでリトライを行うRetryPolicy
+type ExponentialBackoffPolicy struct {
+	// InitialInterval 初回リトライまでの待ち時間
+	InitialInterval time.Duration
+	// MaxInterval 待ち時間の上限、0の場合は無制限
+	MaxInterval time.Duration
+	// Multiplier 試行ごとの待ち時間の倍率、1未満の場合は2
+	Multiplier float64
+	// Jitter 待ち時間に加えるゆらぎの割合(0〜1)、例えば0.2の場合は待ち時間の±20%の範囲でランダムに変動させる
+	Jitter float64
+	// MaxAttempts 最大試行回数(初回を含む)、0以下の場合は無制限
+	MaxAttempts int
+	// MaxElapsedTime 最初の試行からの経過時間の上限、0の場合は無制限
+	MaxElapsedTime time.Duration
+	// Classifier リトライ可能なエラーの判定、省略時はDefaultErrorClassifier
+	Classifier ErrorClassifier
+}
+
+// NextDelay RetryPolicyの実装
+func (p *ExponentialBackoffPolicy) NextDelay(attempt int, elapsed time.Duration, err error) (time.Duration, bool) {
+	if !classify(p.Classifier, err) {
+		return 0, false
+	}
+	if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	delay := float64(p.InitialInterval)
+	for i := 1; i < attempt; i++ {
+		delay *= multiplier
+		if p.MaxInterval > 0 && delay >= float64(p.MaxInterval) {
+			delay = float64(p.MaxInterval)
+			break
+		}
+	}
+	if p.MaxInterval > 0 && delay > float64(p.MaxInterval) {
+		delay = float64(p.MaxInterval)
+	}
+	if p.Jitter > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		delay += delay * jitter * (rand.Float64()*2 - 1) //nolint:gosec
+	}
+
+	d := time.Duration(delay)
+	if p.MaxElapsedTime > 0 && elapsed+d > p.MaxElapsedTime {
+		return 0, false
+	}
+	return d, true
+}
+
+func classify(classifier ErrorClassifier, err error) bool {
+	if classifier == nil {
+		classifier = DefaultErrorClassifier
+	}
+	return classifier(err)
+}
+
+// Retry policyに従いfnが成功するまで試行する
+//
+// 待機中にctxがキャンセルされた場合はctx.Err()を返す。
+// ポリシーによりリトライしないと判定された場合は最後に発生したエラーを返す。
+func Retry(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		delay, ok := policy.NextDelay(attempt, time.Since(start), err)
+		if !ok {
+			return err
+		}
+		if err := Sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// Sleep 指定の時間待機する。待機中にctxがキャンセルされた場合はctx.Err()を返す
+func Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package setup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixedIntervalPolicy(t *testing.T) {
+	policy := &FixedIntervalPolicy{MaxAttempts: 3, Interval: time.Second}
+	err := errors.New("dummy")
+
+	for _, attempt := range []int{1, 2} {
+		delay, ok := policy.NextDelay(attempt, 0, err)
+		require.True(t, ok)
+		require.Equal(t, time.Second, delay)
+	}
+
+	_, ok := policy.NextDelay(3, 0, err)
+	require.False(t, ok)
+
+	_, ok = policy.NextDelay(1, 0, context.Canceled)
+	require.False(t, ok)
+}
+
+func TestExponentialBackoffPolicy(t *testing.T) {
+	err := errors.New("dummy")
+
+	t.Run("backoff", func(t *testing.T) {
+		policy := &ExponentialBackoffPolicy{
+			InitialInterval: time.Second,
+			MaxInterval:     5 * time.Second,
+		}
+		expects := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+		for i, expect := range expects {
+			delay, ok := policy.NextDelay(i+1, 0, err)
+			require.True(t, ok)
+			require.Equal(t, expect, delay)
+		}
+	})
+
+	t.Run("jitter", func(t *testing.T) {
+		policy := &ExponentialBackoffPolicy{
+			InitialInterval: time.Second,
+			Multiplier:      3,
+			Jitter:          0.5,
+		}
+		for i := 0; i < 100; i++ {
+			delay, ok := policy.NextDelay(2, 0, err)
+			require.True(t, ok)
+			require.GreaterOrEqual(t, delay, 1500*time.Millisecond)
+			require.LessOrEqual(t, delay, 4500*time.Millisecond)
+		}
+	})
+
+	t.Run("max attempts and elapsed time", func(t *testing.T) {
+		policy := &ExponentialBackoffPolicy{
+			InitialInterval: time.Second,
+			MaxAttempts:     3,
+			MaxElapsedTime:  10 * time.Second,
+		}
+		_, ok := policy.NextDelay(3, 0, err)
+		require.False(t, ok)
+
+		_, ok = policy.NextDelay(2, 9*time.Second, err)
+		require.False(t, ok)
+
+		delay, ok := policy.NextDelay(2, 7*time.Second, err)
+		require.True(t, ok)
+		require.Equal(t, 2*time.Second, delay)
+	})
+
+	t.Run("classifier", func(t *testing.T) {
+		retryable := errors.New("retryable")
+		policy := &ExponentialBackoffPolicy{
+			InitialInterval: time.Second,
+			Classifier: func(err error) bool {
+				return errors.Is(err, retryable)
+			},
+		}
+		_, ok := policy.NextDelay(1, 0, retryable)
+		require.True(t, ok)
+
+		_, ok = policy.NextDelay(1, 0, err)
+		require.False(t, ok)
+	})
+}
+
+func TestRetry(t *testing.T) {
+	policy := &FixedIntervalPolicy{MaxAttempts: 3, Interval: time.Millisecond}
+
+	t.Run("succeeded after retry", func(t *testing.T) {
+		count := 0
+		err := Retry(context.Background(), policy, func(context.Context) error {
+			count++
+			if count < 3 {
+				return errors.New("dummy")
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+	})
+
+	t.Run("max attempts exceeded", func(t *testing.T) {
+		count := 0
+		err := Retry(context.Background(), policy, func(context.Context) error {
+			count++
+			return errors.New("dummy")
+		})
+		require.EqualError(t, err, "dummy")
+		require.Equal(t, 3, count)
+	})
+
+	t.Run("context canceled while waiting", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		count := 0
+		err := Retry(ctx, &FixedIntervalPolicy{Interval: time.Hour}, func(context.Context) error {
+			count++
+			cancel()
+			return errors.New("dummy")
+		})
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, 1, count)
+	})
+}
+
+func TestSleep(t *testing.T) {
+	require.NoError(t, Sleep(context.Background(), time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, Sleep(ctx, time.Hour), context.DeadlineExceeded)
+}
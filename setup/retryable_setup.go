@@ -23,11 +23,15 @@ import (
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/accessor"
 	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/progress"
 )
 
 // MaxRetryCountExceededError リトライ最大数超過エラー
 type MaxRetryCountExceededError error
 
+// errResourceFailed リソース作成後にAvailabilityがFailedになった場合にRetryPolicyへ渡すエラー
+var errResourceFailed = errors.New("resource availability became Failed")
+
 // CreateFunc リソース作成関数
 type CreateFunc func(ctx context.Context, zone string) (accessor.ID, error)
 
@@ -74,27 +78,34 @@ func (r *RetryableSetup) Setup(ctx context.Context, zone string) (interface{}, e
 
 	r.init()
 
+	policy := r.Options.retryPolicy()
+	start := time.Now()
+
 	var created interface{}
-	for r.Options.RetryCount+1 > 0 {
-		r.Options.RetryCount--
+	var resource string
+	var id types.ID
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 
 		// リソース作成
 		target, err := r.createResource(ctx, zone)
 		if err != nil {
 			return nil, err
 		}
-		id := target.GetID()
+		id = target.GetID()
+		resource = progress.ResourceName(target)
+		progress.Emit(ctx, &progress.Event{Type: progress.EventCreated, Resource: resource, Zone: zone, ID: id, Attempt: attempt})
 
 		// コピー待ち
 		if r.IsWaitForCopy {
 			// コピー待ち、Failedになった場合はリソース削除
-			state, err := r.waitForCopyWithCleanup(ctx, zone, id)
+			state, err := r.waitForCopyWithCleanup(ctx, zone, id, resource)
 			if err != nil {
 				return state, err
 			}
-			if state != nil {
-				created = state
-			}
+			created = state
 		} else {
 			created = target
 		}
@@ -112,11 +123,28 @@ func (r *RetryableSetup) Setup(ctx context.Context, zone string) (interface{}, e
 		if created != nil {
 			break
 		}
+
+		delay, ok := policy.NextDelay(attempt, time.Since(start), errResourceFailed)
+		if !ok {
+			break
+		}
+		progress.Emit(ctx, &progress.Event{
+			Type:     progress.EventRetrying,
+			Resource: resource,
+			Zone:     zone,
+			ID:       id,
+			Attempt:  attempt + 1,
+			Message:  errResourceFailed.Error(),
+		})
+		if err := Sleep(ctx, delay); err != nil {
+			return nil, err
+		}
 	}
 
 	if created == nil {
 		return nil, MaxRetryCountExceededError(fmt.Errorf("max retry count exceeded"))
 	}
+	progress.Emit(ctx, &progress.Event{Type: progress.EventReady, Resource: resource, Zone: zone, ID: id})
 	return created, nil
 }
 
@@ -134,7 +162,7 @@ func (r *RetryableSetup) createResource(ctx context.Context, zone string) (acces
 	return r.Create(ctx, zone)
 }
 
-func (r *RetryableSetup) waitForCopyWithCleanup(ctx context.Context, zone string, id types.ID) (interface{}, error) {
+func (r *RetryableSetup) waitForCopyWithCleanup(ctx context.Context, zone string, id types.ID, resource string) (interface{}, error) {
 	waiter := &iaas.StatePollingWaiter{
 		ReadFunc: func() (interface{}, error) {
 			return r.Read(ctx, zone, id)
@@ -166,6 +194,13 @@ loop:
 			break loop
 		case v := <-progressChan:
 			state = v
+			progress.Emit(ctx, &progress.Event{
+				Type:     progress.EventCopying,
+				Resource: resource,
+				Zone:     zone,
+				ID:       id,
+				Percent:  progress.CopyPercent(v),
+			})
 		case e := <-errChan:
 			err = e
 			break loop
@@ -177,12 +212,16 @@ loop:
 		if f, ok := state.(accessor.Availability); ok && f != nil {
 			if f.GetAvailability().IsFailed() {
 				// FailedになったばかりだとDelete APIが失敗する(コピー進行中など)場合があるため、
-				// 任意の回数リトライ&待機を行う
-				for i := 0; i < r.Options.DeleteRetryCount; i++ {
-					time.Sleep(r.Options.DeleteRetryInterval)
-					if err = r.Delete(ctx, zone, id); err == nil {
-						break
-					}
+				// 待機後にリトライポリシーに従いリトライを行う
+				if err := Sleep(ctx, r.Options.DeleteRetryInterval); err != nil {
+					return nil, err
+				}
+				progress.Emit(ctx, &progress.Event{Type: progress.EventDeleting, Resource: resource, Zone: zone, ID: id})
+				// 削除に失敗した場合もコンテキストがキャンセルされていなければリソースの再作成を継続する
+				if err := Retry(ctx, r.Options.deleteRetryPolicy(), func(ctx context.Context) error {
+					return r.Delete(ctx, zone, id)
+				}); err != nil && ctx.Err() != nil {
+					return nil, ctx.Err()
 				}
 
 				return nil, nil
@@ -200,20 +239,17 @@ loop:
 
 func (r *RetryableSetup) provisionBeforeUp(ctx context.Context, zone string, id types.ID, created interface{}) error {
 	if r.ProvisionBeforeUp != nil && created != nil {
-		var err error
-		for i := 0; i < r.Options.ProvisioningRetryCount; i++ {
-			if err = r.ProvisionBeforeUp(ctx, zone, id, created); err == nil {
-				break
-			}
-			time.Sleep(r.Options.ProvisioningRetryInterval)
-		}
-		return err
+		return Retry(ctx, r.Options.provisioningRetryPolicy(), func(ctx context.Context) error {
+			return r.ProvisionBeforeUp(ctx, zone, id, created)
+		})
 	}
 	return nil
 }
 
 func (r *RetryableSetup) waitForUp(ctx context.Context, zone string, id types.ID, created interface{}) error {
 	if r.IsWaitForUp && created != nil {
+		progress.Emit(ctx, &progress.Event{Type: progress.EventBooting, Resource: progress.ResourceName(created), Zone: zone, ID: id})
+
 		waiter := &iaas.StatePollingWaiter{
 			ReadFunc: func() (interface{}, error) {
 				return r.Read(ctx, zone, id)
@@ -22,6 +22,7 @@ import (
 
 	"github.com/sacloud/iaas-api-go/accessor"
 	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/progress"
 	"github.com/stretchr/testify/require"
 )
 
@@ -152,9 +153,60 @@ func TestRetryableSetup_Setup(t *testing.T) {
 			_, ok := err.(MaxRetryCountExceededError)
 			require.True(t, ok)
 		})
+
+		t.Run("retry policy", func(t *testing.T) {
+			var delays []time.Duration
+			policy := &recordingRetryPolicy{
+				policy: &FixedIntervalPolicy{MaxAttempts: 2, Interval: time.Millisecond},
+				delays: &delays,
+			}
+			createCount := 0
+			retryable := &RetryableSetup{
+				Create: func(context.Context, string) (id accessor.ID, e error) {
+					createCount++
+					return &dummyIDAccessor{id: 1}, nil
+				},
+				IsWaitForCopy: true,
+				Delete: func(context.Context, string, types.ID) error {
+					return nil
+				},
+				Read: withErrorReadFunc(func(ctx context.Context, zone string, id types.ID) (interface{}, error) {
+					return &dummyIDAccessor{id: 1}, nil
+				}, 5),
+				Options: &Options{
+					RetryCount:                3,
+					RetryPolicy:               policy,
+					ProvisioningRetryInterval: time.Millisecond,
+					DeleteRetryInterval:       time.Millisecond,
+					PollingInterval:           time.Millisecond,
+				},
+			}
+
+			_, err := retryable.Setup(ctx, zone)
+
+			require.Error(t, err)
+			_, ok := err.(MaxRetryCountExceededError)
+			require.True(t, ok)
+			// RetryCountよりRetryPolicyが優先される
+			require.Equal(t, 2, createCount)
+			require.Equal(t, []time.Duration{time.Millisecond}, delays)
+		})
 	})
 }
 
+type recordingRetryPolicy struct {
+	policy RetryPolicy
+	delays *[]time.Duration
+}
+
+func (p *recordingRetryPolicy) NextDelay(attempt int, elapsed time.Duration, err error) (time.Duration, bool) {
+	delay, ok := p.policy.NextDelay(attempt, elapsed, err)
+	if ok {
+		*p.delays = append(*p.delays, delay)
+	}
+	return delay, ok
+}
+
 func withErrorReadFunc(readFunc ReadFunc, errCount int) ReadFunc {
 	maxErr := errCount
 	return func(ctx context.Context, zone string, id types.ID) (interface{}, error) {
@@ -165,3 +217,41 @@ func withErrorReadFunc(readFunc ReadFunc, errCount int) ReadFunc {
 		return &dummyAvailabilityAccessor{available: types.Availabilities.Failed}, nil
 	}
 }
+
+func TestRetryableSetup_Progress(t *testing.T) {
+	var events []progress.EventType
+	ctx := progress.WithObserver(context.Background(), progress.ObserverFunc(func(event *progress.Event) {
+		if event.Type != progress.EventCopying {
+			events = append(events, event.Type)
+		}
+	}))
+
+	retryable := &RetryableSetup{
+		Create: func(context.Context, string) (id accessor.ID, e error) {
+			return &dummyIDAccessor{id: 1}, nil
+		},
+		IsWaitForCopy: true,
+		Delete: func(context.Context, string, types.ID) error {
+			return nil
+		},
+		Read: withErrorReadFunc(func(ctx context.Context, zone string, id types.ID) (interface{}, error) {
+			return &dummyIDAccessor{id: 1}, nil
+		}, 2),
+		Options: &Options{
+			RetryCount:                3,
+			ProvisioningRetryInterval: time.Millisecond,
+			DeleteRetryInterval:       time.Millisecond,
+			PollingInterval:           time.Millisecond,
+		},
+	}
+
+	_, err := retryable.Setup(ctx, "tk1v")
+	require.NoError(t, err)
+	require.Equal(t, []progress.EventType{
+		progress.EventCreated,
+		progress.EventDeleting,
+		progress.EventRetrying,
+		progress.EventCreated,
+		progress.EventReady,
+	}, events)
+}
@@ -14,7 +14,10 @@
 
 package sim
 
-import "github.com/sacloud/iaas-api-go"
+import (
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
 
 // Service provides a high-level API of for SIM
 type Service struct {
@@ -25,3 +28,13 @@ type Service struct {
 func New(caller iaas.APICaller) *Service {
 	return &Service{caller: caller}
 }
+
+var (
+	_ service.Creator[*CreateRequest, *iaas.SIM]    = (*Service)(nil)
+	_ service.Reader[*ReadRequest, *iaas.SIM]       = (*Service)(nil)
+	_ service.Updater[*UpdateRequest, *iaas.SIM]    = (*Service)(nil)
+	_ service.Deleter[*DeleteRequest]               = (*Service)(nil)
+	_ service.Finder[*FindRequest, *iaas.SIM]       = (*Service)(nil)
+	_ service.FindIterator[*FindRequest, *iaas.SIM] = (*Service)(nil)
+	_ service.Applier[*ApplyRequest, *iaas.SIM]     = (*Service)(nil)
+)
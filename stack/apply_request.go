@@ -0,0 +1,149 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sacloud/packages-go/validate"
+)
+
+// ApplyRequest Applyサービスへのパラメータ
+type ApplyRequest struct {
+	Resources []Resource `validate:"required"`
+
+	// Parallelism 同時に適用するリソース数の上限、0の場合は無制限
+	Parallelism int `validate:"min=0"`
+}
+
+func (req *ApplyRequest) Validate() error {
+	if err := validate.New().Struct(req); err != nil {
+		return err
+	}
+	_, err := req.Plan()
+	return err
+}
+
+// Plan 依存関係を解決し、適用順に並べた論理名のリストを返す
+//
+// 戻り値の各要素はそれぞれ並列に適用可能なリソースのグループを表す
+func (req *ApplyRequest) Plan() ([][]string, error) {
+	g, err := req.graph()
+	if err != nil {
+		return nil, err
+	}
+
+	var results [][]string
+	remaining := map[string]int{}
+	for name, deps := range g.dependencies {
+		remaining[name] = len(deps)
+	}
+	current := g.roots()
+	for len(current) > 0 {
+		results = append(results, current)
+
+		var next []string
+		for _, name := range current {
+			delete(remaining, name)
+			for _, dependent := range g.dependents[name] {
+				remaining[dependent]--
+				if remaining[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		sort.Slice(next, func(i, j int) bool { return g.order[next[i]] < g.order[next[j]] })
+		current = next
+	}
+
+	if len(remaining) > 0 {
+		var names []string
+		for name := range remaining {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("circular dependency detected: %s", strings.Join(names, ", "))
+	}
+	return results, nil
+}
+
+type graph struct {
+	resources    map[string]Resource
+	order        map[string]int
+	dependencies map[string][]string
+	dependents   map[string][]string
+}
+
+func (req *ApplyRequest) graph() (*graph, error) {
+	g := &graph{
+		resources:    map[string]Resource{},
+		order:        map[string]int{},
+		dependencies: map[string][]string{},
+		dependents:   map[string][]string{},
+	}
+
+	for i, r := range req.Resources {
+		if r == nil {
+			return nil, fmt.Errorf("resources[%d] is nil", i)
+		}
+		name := r.LogicalName()
+		if name == "" {
+			return nil, fmt.Errorf("resources[%d]: logical name is required", i)
+		}
+		if _, ok := g.resources[name]; ok {
+			return nil, fmt.Errorf("resources[%d]: duplicated logical name: %s", i, name)
+		}
+		g.resources[name] = r
+		g.order[name] = i
+	}
+
+	for _, r := range req.Resources {
+		name := r.LogicalName()
+		seen := map[string]bool{}
+		for _, dep := range r.Dependencies() {
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+
+			if dep == name {
+				return nil, fmt.Errorf("resource %q depends on itself", name)
+			}
+			if _, ok := g.resources[dep]; !ok {
+				return nil, fmt.Errorf("resource %q depends on undefined resource %q", name, dep)
+			}
+			g.dependencies[name] = append(g.dependencies[name], dep)
+			g.dependents[dep] = append(g.dependents[dep], name)
+		}
+		if _, ok := g.dependencies[name]; !ok {
+			g.dependencies[name] = nil
+		}
+	}
+	return g, nil
+}
+
+// roots 依存先を持たないリソースの論理名を定義順で返す
+func (g *graph) roots() []string {
+	var names []string
+	for name, deps := range g.dependencies {
+		if len(deps) == 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return g.order[names[i]] < g.order[names[j]] })
+	return names
+}
@@ -0,0 +1,104 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Apply スタックに含まれるリソースを依存関係順に作成/更新する
+//
+// 依存関係のないリソース同士は並列に適用される。
+// いずれかのリソースの適用に失敗した場合、実行中のものの完了を待ち、以降のリソースは適用しない。
+// その場合もそれまでに適用できたリソースの参照情報を返す。
+func (s *Service) Apply(req *ApplyRequest) (References, error) {
+	return s.ApplyWithContext(context.Background(), req)
+}
+
+func (s *Service) ApplyWithContext(ctx context.Context, req *ApplyRequest) (References, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	g, err := req.graph()
+	if err != nil {
+		return nil, err
+	}
+
+	type applyResult struct {
+		name string
+		ref  *Reference
+		err  error
+	}
+
+	var semaphore chan struct{}
+	if req.Parallelism > 0 {
+		semaphore = make(chan struct{}, req.Parallelism)
+	}
+	resultCh := make(chan *applyResult)
+	refs := References{}
+	running := 0
+
+	start := func(name string) {
+		running++
+		resource := g.resources[name]
+		snapshot := refs.clone()
+		go func() {
+			if semaphore != nil {
+				semaphore <- struct{}{}
+				defer func() { <-semaphore }()
+			}
+			ref, err := resource.Apply(ctx, s.caller, snapshot)
+			resultCh <- &applyResult{name: name, ref: ref, err: err}
+		}()
+	}
+
+	remaining := map[string]int{}
+	for name, deps := range g.dependencies {
+		remaining[name] = len(deps)
+	}
+	for _, name := range g.roots() {
+		start(name)
+	}
+
+	var errs []error
+	for running > 0 {
+		result := <-resultCh
+		running--
+
+		if result.err != nil {
+			errs = append(errs, fmt.Errorf("applying resource %q failed: %w", result.name, result.err))
+			continue
+		}
+		refs[result.name] = result.ref
+
+		if len(errs) > 0 || ctx.Err() != nil {
+			continue
+		}
+		for _, dependent := range g.dependents[result.name] {
+			remaining[dependent]--
+			if remaining[dependent] == 0 {
+				start(dependent)
+			}
+		}
+	}
+
+	if len(errs) == 0 && len(refs) < len(g.resources) {
+		errs = append(errs, ctx.Err())
+	}
+	return refs, errors.Join(errs...)
+}
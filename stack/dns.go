@@ -0,0 +1,125 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/dns"
+)
+
+// DNS スタック内のDNSゾーンの定義
+type DNS struct {
+	Name      string   // 論理名
+	DependsOn []string // 明示的に依存する他リソースの論理名
+
+	ID      types.ID // 既存のゾーンを更新する場合に指定する
+	Request *dns.CreateRequest
+
+	// Records スタック内のサーバのIPアドレスを参照するAレコード
+	//
+	// Request.Recordsに追加される
+	Records []*DNSRecordRef
+}
+
+// DNSRecordRef DNSレコードからスタック内のサーバへの参照
+type DNSRecordRef struct {
+	Name   string // レコード名
+	TTL    int
+	Server string // サーバの論理名、eth0のIPアドレスがRDataとなる
+}
+
+// LogicalName 論理名を返す
+func (r *DNS) LogicalName() string {
+	return r.Name
+}
+
+// Dependencies 依存する他リソースの論理名のリストを返す
+func (r *DNS) Dependencies() []string {
+	deps := append([]string{}, r.DependsOn...)
+	for _, record := range r.Records {
+		deps = appendIfNotEmpty(deps, record.Server)
+	}
+	return deps
+}
+
+// Apply DNSゾーンの作成/更新を行う
+func (r *DNS) Apply(ctx context.Context, caller iaas.APICaller, refs References) (*Reference, error) {
+	if r.Request == nil {
+		return nil, errors.New("request is required")
+	}
+	records, err := r.resolveRecords(refs)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := dns.New(caller)
+	var zone *iaas.DNS
+	if r.ID.IsEmpty() {
+		req := *r.Request
+		req.Records = records
+		zone, err = svc.CreateWithContext(ctx, &req)
+	} else {
+		zone, err = svc.UpdateWithContext(ctx, &dns.UpdateRequest{
+			ID:                 r.ID,
+			Description:        &r.Request.Description,
+			Tags:               &r.Request.Tags,
+			IconID:             &r.Request.IconID,
+			Records:            records,
+			MonitoringSuiteLog: r.Request.MonitoringSuiteLog,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Reference{ID: zone.ID, Value: zone}, nil
+}
+
+func (r *DNS) resolveRecords(refs References) (iaas.DNSRecords, error) {
+	records := append(iaas.DNSRecords{}, r.Request.Records...)
+	for _, record := range r.Records {
+		ref, ok := refs[record.Server]
+		if !ok || ref == nil {
+			return nil, fmt.Errorf("resource %q is not found in stack", record.Server)
+		}
+		sv, ok := ref.Value.(*iaas.Server)
+		if !ok {
+			return nil, fmt.Errorf("resource %q is not a server", record.Server)
+		}
+
+		var ip string
+		if len(sv.Interfaces) > 0 {
+			ip = sv.Interfaces[0].IPAddress
+			if ip == "" {
+				ip = sv.Interfaces[0].UserIPAddress
+			}
+		}
+		if ip == "" {
+			return nil, fmt.Errorf("server %q does not have an IP address on eth0", record.Server)
+		}
+
+		records = append(records, &iaas.DNSRecord{
+			Name:  record.Name,
+			Type:  types.DNSRecordTypes.A,
+			RData: ip,
+			TTL:   record.TTL,
+		})
+	}
+	return records, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/packetfilter"
+)
+
+// PacketFilter スタック内のパケットフィルタの定義
+type PacketFilter struct {
+	Name      string   // 論理名
+	DependsOn []string // 明示的に依存する他リソースの論理名
+
+	ID      types.ID // 既存のパケットフィルタを更新する場合に指定する
+	Request *packetfilter.CreateRequest
+}
+
+// LogicalName 論理名を返す
+func (r *PacketFilter) LogicalName() string {
+	return r.Name
+}
+
+// Dependencies 依存する他リソースの論理名のリストを返す
+func (r *PacketFilter) Dependencies() []string {
+	return r.DependsOn
+}
+
+// Apply パケットフィルタの作成/更新を行う
+func (r *PacketFilter) Apply(ctx context.Context, caller iaas.APICaller, _ References) (*Reference, error) {
+	if r.Request == nil {
+		return nil, errors.New("request is required")
+	}
+	svc := packetfilter.New(caller)
+
+	var pf *iaas.PacketFilter
+	var err error
+	if r.ID.IsEmpty() {
+		pf, err = svc.CreateWithContext(ctx, r.Request)
+	} else {
+		pf, err = svc.UpdateWithContext(ctx, &packetfilter.UpdateRequest{
+			Zone:        r.Request.Zone,
+			ID:          r.ID,
+			Name:        &r.Request.Name,
+			Description: &r.Request.Description,
+			Expression:  &r.Request.Expression,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Reference{Zone: r.Request.Zone, ID: pf.ID, Value: pf}, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// Resource スタックを構成するリソースの定義
+type Resource interface {
+	// LogicalName スタック内でリソースを識別するための論理名
+	LogicalName() string
+	// Dependencies 依存する他リソースの論理名のリスト
+	Dependencies() []string
+	// Apply リソースの作成/更新を行う
+	//
+	// refsには依存するリソースの適用結果が格納されている
+	Apply(ctx context.Context, caller iaas.APICaller, refs References) (*Reference, error)
+}
+
+// Reference 適用済みリソースの参照情報
+type Reference struct {
+	Zone  string // グローバルリソースの場合は空
+	ID    types.ID
+	Value interface{} // 適用後のリソース(*iaas.Serverなど)
+}
+
+// References 論理名をキーとする適用済みリソースの参照情報
+type References map[string]*Reference
+
+// Lookup 論理名からリソースのIDを取得する
+//
+// zoneが指定された場合、参照先リソースのゾーンと異なる場合はエラーを返す
+func (r References) Lookup(name, zone string) (types.ID, error) {
+	ref, ok := r[name]
+	if !ok || ref == nil {
+		return types.ID(0), fmt.Errorf("resource %q is not found in stack", name)
+	}
+	if zone != "" && ref.Zone != "" && zone != ref.Zone {
+		return types.ID(0), fmt.Errorf("resource %q is in a different zone: want=%s got=%s", name, zone, ref.Zone)
+	}
+	return ref.ID, nil
+}
+
+func (r References) clone() References {
+	cloned := References{}
+	for k, v := range r {
+		cloned[k] = v
+	}
+	return cloned
+}
+
+func appendIfNotEmpty(names []string, values ...string) []string {
+	for _, v := range values {
+		if v != "" {
+			names = append(names, v)
+		}
+	}
+	return names
+}
@@ -0,0 +1,113 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-service-go/server"
+)
+
+// Server スタック内のサーバの定義
+type Server struct {
+	Name      string   // 論理名
+	DependsOn []string // 明示的に依存する他リソースの論理名
+
+	Request *server.ApplyRequest
+
+	// NetworkInterfaces スタック内の他リソースを参照するNICの設定
+	//
+	// Request.NetworkInterfacesの同じインデックスの要素のUpstream/PacketFilterIDを参照先リソースのIDで上書きする
+	NetworkInterfaces []*ServerNetworkInterfaceRef
+}
+
+// ServerNetworkInterfaceRef サーバのNICからスタック内の他リソースへの参照
+type ServerNetworkInterfaceRef struct {
+	Index        int    // NICのインデックス(0: eth0)
+	Switch       string // 接続先スイッチの論理名
+	PacketFilter string // 接続するパケットフィルタの論理名
+}
+
+// LogicalName 論理名を返す
+func (r *Server) LogicalName() string {
+	return r.Name
+}
+
+// Dependencies 依存する他リソースの論理名のリストを返す
+func (r *Server) Dependencies() []string {
+	deps := append([]string{}, r.DependsOn...)
+	for _, nic := range r.NetworkInterfaces {
+		deps = appendIfNotEmpty(deps, nic.Switch, nic.PacketFilter)
+	}
+	return deps
+}
+
+// Apply サーバの作成/更新を行う
+func (r *Server) Apply(ctx context.Context, caller iaas.APICaller, refs References) (*Reference, error) {
+	if r.Request == nil {
+		return nil, errors.New("request is required")
+	}
+	req, err := r.resolve(refs)
+	if err != nil {
+		return nil, err
+	}
+
+	sv, err := server.New(caller).ApplyWithContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &Reference{Zone: req.Zone, ID: sv.ID, Value: sv}, nil
+}
+
+// resolve 参照を解決したApplyRequestを返す、r.Requestは変更しない
+func (r *Server) resolve(refs References) (*server.ApplyRequest, error) {
+	req := *r.Request
+	nics := make([]*server.NetworkInterface, len(req.NetworkInterfaces))
+	for i, nic := range req.NetworkInterfaces {
+		n := &server.NetworkInterface{}
+		if nic != nil {
+			*n = *nic
+		}
+		nics[i] = n
+	}
+
+	for _, ref := range r.NetworkInterfaces {
+		if ref.Index < 0 || ref.Index > 9 {
+			return nil, fmt.Errorf("invalid NetworkInterfaces.Index: %d", ref.Index)
+		}
+		for len(nics) <= ref.Index {
+			nics = append(nics, &server.NetworkInterface{})
+		}
+		if ref.Switch != "" {
+			id, err := refs.Lookup(ref.Switch, req.Zone)
+			if err != nil {
+				return nil, err
+			}
+			nics[ref.Index].Upstream = id.String()
+		}
+		if ref.PacketFilter != "" {
+			id, err := refs.Lookup(ref.PacketFilter, req.Zone)
+			if err != nil {
+				return nil, err
+			}
+			nics[ref.Index].PacketFilterID = id
+		}
+	}
+	req.NetworkInterfaces = nics
+	return &req, nil
+}
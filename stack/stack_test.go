@@ -0,0 +1,204 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/server"
+	"github.com/stretchr/testify/require"
+)
+
+type dummyResource struct {
+	name string
+	deps []string
+	id   types.ID
+	err  error
+
+	mu      *sync.Mutex
+	applied *[]string
+}
+
+func (d *dummyResource) LogicalName() string {
+	return d.name
+}
+
+func (d *dummyResource) Dependencies() []string {
+	return d.deps
+}
+
+func (d *dummyResource) Apply(ctx context.Context, caller iaas.APICaller, refs References) (*Reference, error) {
+	for _, dep := range d.deps {
+		if _, ok := refs[dep]; !ok {
+			return nil, errors.New("dependency is not applied yet: " + dep)
+		}
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	d.mu.Lock()
+	*d.applied = append(*d.applied, d.name)
+	d.mu.Unlock()
+	return &Reference{Zone: "is1a", ID: d.id}, nil
+}
+
+func dummyResources(defs ...*dummyResource) []Resource {
+	mu := &sync.Mutex{}
+	applied := &[]string{}
+	var resources []Resource
+	for _, d := range defs {
+		d.mu = mu
+		d.applied = applied
+		resources = append(resources, d)
+	}
+	return resources
+}
+
+func TestApplyRequest_Plan(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []Resource
+		want      [][]string
+		wantErr   bool
+	}{
+		{
+			name: "independent",
+			resources: dummyResources(
+				&dummyResource{name: "sw1"},
+				&dummyResource{name: "sw2"},
+			),
+			want: [][]string{{"sw1", "sw2"}},
+		},
+		{
+			name: "dependencies",
+			resources: dummyResources(
+				&dummyResource{name: "dns", deps: []string{"server1", "server2"}},
+				&dummyResource{name: "server1", deps: []string{"sw1", "pf"}},
+				&dummyResource{name: "server2", deps: []string{"sw1"}},
+				&dummyResource{name: "pf"},
+				&dummyResource{name: "sw1"},
+			),
+			want: [][]string{{"pf", "sw1"}, {"server1", "server2"}, {"dns"}},
+		},
+		{
+			name: "duplicated name",
+			resources: dummyResources(
+				&dummyResource{name: "sw1"},
+				&dummyResource{name: "sw1"},
+			),
+			wantErr: true,
+		},
+		{
+			name: "undefined dependency",
+			resources: dummyResources(
+				&dummyResource{name: "server1", deps: []string{"sw1"}},
+			),
+			wantErr: true,
+		},
+		{
+			name: "circular dependency",
+			resources: dummyResources(
+				&dummyResource{name: "a", deps: []string{"c"}},
+				&dummyResource{name: "b", deps: []string{"a"}},
+				&dummyResource{name: "c", deps: []string{"b"}},
+				&dummyResource{name: "d"},
+			),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &ApplyRequest{Resources: tt.resources}
+			got, err := req.Plan()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_Apply(t *testing.T) {
+	t.Run("applies in dependency order", func(t *testing.T) {
+		resources := dummyResources(
+			&dummyResource{name: "server", deps: []string{"sw", "pf"}, id: 3},
+			&dummyResource{name: "sw", id: 1},
+			&dummyResource{name: "pf", id: 2},
+		)
+		refs, err := New(nil).Apply(&ApplyRequest{Resources: resources, Parallelism: 1})
+		require.NoError(t, err)
+		require.Len(t, refs, 3)
+		applied := *resources[0].(*dummyResource).applied
+		require.ElementsMatch(t, []string{"sw", "pf", "server"}, applied)
+		require.Equal(t, "server", applied[2])
+
+		id, err := refs.Lookup("server", "is1a")
+		require.NoError(t, err)
+		require.Equal(t, types.ID(3), id)
+
+		_, err = refs.Lookup("server", "tk1a")
+		require.Error(t, err)
+	})
+
+	t.Run("stops scheduling after failure", func(t *testing.T) {
+		resources := dummyResources(
+			&dummyResource{name: "sw", err: errors.New("dummy")},
+			&dummyResource{name: "server", deps: []string{"sw"}},
+			&dummyResource{name: "pf", id: 2},
+		)
+		refs, err := New(nil).Apply(&ApplyRequest{Resources: resources})
+		require.Error(t, err)
+		require.Contains(t, refs, "pf")
+		require.NotContains(t, refs, "server")
+	})
+}
+
+func TestServer_resolve(t *testing.T) {
+	r := &Server{
+		Name: "server",
+		Request: &server.ApplyRequest{
+			Zone: "is1a",
+			NetworkInterfaces: []*server.NetworkInterface{
+				{Upstream: "shared"},
+			},
+		},
+		NetworkInterfaces: []*ServerNetworkInterfaceRef{
+			{Index: 0, PacketFilter: "pf"},
+			{Index: 1, Switch: "sw"},
+		},
+	}
+	require.ElementsMatch(t, []string{"pf", "sw"}, r.Dependencies())
+
+	req, err := r.resolve(References{
+		"sw": {Zone: "is1a", ID: 1},
+		"pf": {Zone: "is1a", ID: 2},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*server.NetworkInterface{
+		{Upstream: "shared", PacketFilterID: 2},
+		{Upstream: "1"},
+	}, req.NetworkInterfaces)
+
+	// original request is not modified
+	require.Len(t, r.Request.NetworkInterfaces, 1)
+	require.True(t, r.Request.NetworkInterfaces[0].PacketFilterID.IsEmpty())
+}
@@ -0,0 +1,72 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/swytch"
+)
+
+// Switch スタック内のスイッチの定義
+type Switch struct {
+	Name      string   // 論理名
+	DependsOn []string // 明示的に依存する他リソースの論理名
+
+	ID      types.ID // 既存のスイッチを更新する場合に指定する
+	Request *swytch.CreateRequest
+}
+
+// LogicalName 論理名を返す
+func (r *Switch) LogicalName() string {
+	return r.Name
+}
+
+// Dependencies 依存する他リソースの論理名のリストを返す
+func (r *Switch) Dependencies() []string {
+	return r.DependsOn
+}
+
+// Apply スイッチの作成/更新を行う
+func (r *Switch) Apply(ctx context.Context, caller iaas.APICaller, _ References) (*Reference, error) {
+	if r.Request == nil {
+		return nil, errors.New("request is required")
+	}
+	svc := swytch.New(caller)
+
+	var sw *iaas.Switch
+	var err error
+	if r.ID.IsEmpty() {
+		sw, err = svc.CreateWithContext(ctx, r.Request)
+	} else {
+		sw, err = svc.UpdateWithContext(ctx, &swytch.UpdateRequest{
+			Zone:           r.Request.Zone,
+			ID:             r.ID,
+			Name:           &r.Request.Name,
+			Description:    &r.Request.Description,
+			Tags:           &r.Request.Tags,
+			IconID:         &r.Request.IconID,
+			NetworkMaskLen: &r.Request.NetworkMaskLen,
+			DefaultRoute:   &r.Request.DefaultRoute,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Reference{Zone: r.Request.Zone, ID: sw.ID, Value: sw}, nil
+}
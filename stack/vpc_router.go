@@ -0,0 +1,123 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-service-go/vpcrouter"
+	"github.com/sacloud/iaas-service-go/vpcrouter/builder"
+)
+
+// VPCRouter スタック内のVPCルータの定義
+type VPCRouter struct {
+	Name      string   // 論理名
+	DependsOn []string // 明示的に依存する他リソースの論理名
+
+	Request *vpcrouter.ApplyRequest
+
+	// Switches スタック内のスイッチを参照するNICの設定
+	//
+	// Request.NICSetting(Index=0の場合)またはRequest.AdditionalNICSettingsのうち同じIndexを持つ要素のSwitchIDを上書きする
+	Switches []*VPCRouterSwitchRef
+}
+
+// VPCRouterSwitchRef VPCルータのNICからスタック内のスイッチへの参照
+type VPCRouterSwitchRef struct {
+	Index  int    // NICのインデックス(0: eth0)
+	Switch string // 接続先スイッチの論理名
+}
+
+// LogicalName 論理名を返す
+func (r *VPCRouter) LogicalName() string {
+	return r.Name
+}
+
+// Dependencies 依存する他リソースの論理名のリストを返す
+func (r *VPCRouter) Dependencies() []string {
+	deps := append([]string{}, r.DependsOn...)
+	for _, sw := range r.Switches {
+		deps = appendIfNotEmpty(deps, sw.Switch)
+	}
+	return deps
+}
+
+// Apply VPCルータの作成/更新を行う
+func (r *VPCRouter) Apply(ctx context.Context, caller iaas.APICaller, refs References) (*Reference, error) {
+	if r.Request == nil {
+		return nil, errors.New("request is required")
+	}
+	req, err := r.resolve(refs)
+	if err != nil {
+		return nil, err
+	}
+
+	router, err := vpcrouter.New(caller).ApplyWithContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &Reference{Zone: req.Zone, ID: router.ID, Value: router}, nil
+}
+
+// resolve 参照を解決したApplyRequestを返す、r.Requestは変更しない
+func (r *VPCRouter) resolve(refs References) (*vpcrouter.ApplyRequest, error) {
+	req := *r.Request
+	req.AdditionalNICSettings = append([]builder.AdditionalNICSettingHolder{}, r.Request.AdditionalNICSettings...)
+
+	for _, ref := range r.Switches {
+		switchID, err := refs.Lookup(ref.Switch, req.Zone)
+		if err != nil {
+			return nil, err
+		}
+
+		if ref.Index == 0 {
+			nic, ok := req.NICSetting.(*builder.PremiumNICSetting)
+			if !ok {
+				return nil, fmt.Errorf("NICSetting must be *PremiumNICSetting to connect to switch %q", ref.Switch)
+			}
+			copied := *nic
+			copied.SwitchID = switchID
+			req.NICSetting = &copied
+			continue
+		}
+
+		found := false
+		for i, holder := range req.AdditionalNICSettings {
+			switch nic := holder.(type) {
+			case *builder.AdditionalStandardNICSetting:
+				if nic.Index == ref.Index {
+					copied := *nic
+					copied.SwitchID = switchID
+					req.AdditionalNICSettings[i] = &copied
+					found = true
+				}
+			case *builder.AdditionalPremiumNICSetting:
+				if nic.Index == ref.Index {
+					copied := *nic
+					copied.SwitchID = switchID
+					req.AdditionalNICSettings[i] = &copied
+					found = true
+				}
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("AdditionalNICSettings with Index=%d is not found", ref.Index)
+		}
+	}
+	return &req, nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+func (s *Service) AddDHCPStaticMapping(req *AddDHCPStaticMappingRequest) (*iaas.VPCRouter, error) {
+	return s.AddDHCPStaticMappingWithContext(context.Background(), req)
+}
+
+func (s *Service) AddDHCPStaticMappingWithContext(ctx context.Context, req *AddDHCPStaticMappingRequest) (*iaas.VPCRouter, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.updateSettings(ctx, req.Zone, req.ID, req.MaxRetries, func(current *iaas.VPCRouter) (bool, error) {
+		for _, m := range current.Settings.DHCPStaticMapping {
+			if m.IPAddress == req.DHCPStaticMapping.IPAddress && dhcpStaticMappingKey(m) != dhcpStaticMappingKey(req.DHCPStaticMapping) {
+				return false, fmt.Errorf("IP address %s is already mapped to %s", m.IPAddress, m.MACAddress)
+			}
+		}
+		entries, changed, err := addSettingEntry(current.Settings.DHCPStaticMapping, req.DHCPStaticMapping, "DHCP static mapping", dhcpStaticMappingKey, func(a, b *iaas.VPCRouterDHCPStaticMapping) bool {
+			return dhcpStaticMappingKey(a) == dhcpStaticMappingKey(b) && a.IPAddress == b.IPAddress
+		})
+		if err != nil || !changed {
+			return false, err
+		}
+		current.Settings.DHCPStaticMapping = entries
+		return true, nil
+	})
+}
+
+func dhcpStaticMappingKey(v *iaas.VPCRouterDHCPStaticMapping) string {
+	return strings.ToLower(v.MACAddress)
+}
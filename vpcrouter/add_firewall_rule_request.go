@@ -0,0 +1,50 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/packages-go/validate"
+)
+
+// ファイアウォールルールの方向
+const (
+	FirewallDirectionSend    = "send"
+	FirewallDirectionReceive = "receive"
+)
+
+// AddFirewallRuleRequest VPCルータのインターフェースにファイアウォールルールを追加するためのリクエスト
+//
+// 同一のルールが既に存在する場合は更新を行わない
+type AddFirewallRuleRequest struct {
+	Zone string   `validate:"required"`
+	ID   types.ID `validate:"required"`
+
+	// Index 対象のインターフェースのインデックス
+	Index int `validate:"min=0,max=7"`
+	// Direction ルールの方向、FirewallDirectionSendまたはFirewallDirectionReceive
+	Direction string                      `validate:"required,oneof=send receive"`
+	Rule      *iaas.VPCRouterFirewallRule `validate:"required"`
+	// Position ルールを挿入する位置、nilの場合は末尾に追加する(末尾が全拒否ルールの場合はその直前に追加する)
+	Position *int `validate:"omitempty,min=0"`
+
+	// MaxRetries コンフリクト時の最大リトライ回数、0の場合はserviceutil.DefaultConflictRetryCountを利用する
+	MaxRetries int `validate:"min=0"`
+}
+
+func (req *AddFirewallRuleRequest) Validate() error {
+	return validate.New().Struct(req)
+}
@@ -0,0 +1,89 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"context"
+	"slices"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+func (s *Service) AddFirewallRule(req *AddFirewallRuleRequest) (*iaas.VPCRouter, error) {
+	return s.AddFirewallRuleWithContext(context.Background(), req)
+}
+
+func (s *Service) AddFirewallRuleWithContext(ctx context.Context, req *AddFirewallRuleRequest) (*iaas.VPCRouter, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.updateSettings(ctx, req.Zone, req.ID, req.MaxRetries, func(current *iaas.VPCRouter) (bool, error) {
+		firewall := findFirewall(current.Settings, req.Index)
+		if firewall == nil {
+			firewall = &iaas.VPCRouterFirewall{Index: req.Index}
+			current.Settings.Firewall = append(current.Settings.Firewall, firewall)
+		}
+		rules := firewallRules(firewall, req.Direction)
+		for _, rule := range *rules {
+			if *rule == *req.Rule {
+				return false, nil
+			}
+		}
+
+		*rules = insertFirewallRule(*rules, req.Rule, req.Position)
+		return true, nil
+	})
+}
+
+// insertFirewallRule ruleをpositionの位置に挿入する
+//
+// positionがnilの場合は末尾に追加する。ただし末尾が全拒否ルールの場合は、
+// その後ろに追加したルールは評価されないため全拒否ルールの直前に挿入する
+func insertFirewallRule(rules []*iaas.VPCRouterFirewallRule, rule *iaas.VPCRouterFirewallRule, position *int) []*iaas.VPCRouterFirewallRule {
+	index := len(rules)
+	switch {
+	case position != nil:
+		index = min(*position, index)
+	case index > 0 && isDenyAllFirewallRule(rules[index-1]):
+		index--
+	}
+	return slices.Insert(rules, index, rule)
+}
+
+func isDenyAllFirewallRule(rule *iaas.VPCRouterFirewallRule) bool {
+	isAnyNetwork := func(network types.VPCFirewallNetwork) bool {
+		return network == "" || network == "0.0.0.0/0"
+	}
+	return rule.Action == types.Actions.Deny && rule.Protocol == types.Protocols.IP &&
+		isAnyNetwork(rule.SourceNetwork) && isAnyNetwork(rule.DestinationNetwork)
+}
+
+func findFirewall(settings *iaas.VPCRouterSetting, index int) *iaas.VPCRouterFirewall {
+	for _, firewall := range settings.Firewall {
+		if firewall.Index == index {
+			return firewall
+		}
+	}
+	return nil
+}
+
+func firewallRules(firewall *iaas.VPCRouterFirewall, direction string) *[]*iaas.VPCRouterFirewallRule {
+	if direction == FirewallDirectionSend {
+		return &firewall.Send
+	}
+	return &firewall.Receive
+}
@@ -0,0 +1,70 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertFirewallRule(t *testing.T) {
+	allowSSH := &iaas.VPCRouterFirewallRule{Protocol: types.Protocols.TCP, DestinationPort: "22", Action: types.Actions.Allow}
+	allowHTTP := &iaas.VPCRouterFirewallRule{Protocol: types.Protocols.TCP, DestinationPort: "80", Action: types.Actions.Allow}
+	denyAll := &iaas.VPCRouterFirewallRule{Protocol: types.Protocols.IP, Action: types.Actions.Deny}
+	position := func(v int) *int { return &v }
+
+	cases := []struct {
+		name     string
+		rules    []*iaas.VPCRouterFirewallRule
+		position *int
+		expect   []*iaas.VPCRouterFirewallRule
+	}{
+		{
+			name:   "empty",
+			expect: []*iaas.VPCRouterFirewallRule{allowHTTP},
+		},
+		{
+			name:   "append",
+			rules:  []*iaas.VPCRouterFirewallRule{allowSSH},
+			expect: []*iaas.VPCRouterFirewallRule{allowSSH, allowHTTP},
+		},
+		{
+			name:   "before trailing deny all",
+			rules:  []*iaas.VPCRouterFirewallRule{allowSSH, denyAll},
+			expect: []*iaas.VPCRouterFirewallRule{allowSSH, allowHTTP, denyAll},
+		},
+		{
+			name:     "with position",
+			rules:    []*iaas.VPCRouterFirewallRule{allowSSH, denyAll},
+			position: position(0),
+			expect:   []*iaas.VPCRouterFirewallRule{allowHTTP, allowSSH, denyAll},
+		},
+		{
+			name:     "position after deny all",
+			rules:    []*iaas.VPCRouterFirewallRule{allowSSH, denyAll},
+			position: position(10),
+			expect:   []*iaas.VPCRouterFirewallRule{allowSSH, denyAll, allowHTTP},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expect, insertFirewallRule(tc.rules, allowHTTP, tc.position))
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+func (s *Service) AddPortForwarding(req *AddPortForwardingRequest) (*iaas.VPCRouter, error) {
+	return s.AddPortForwardingWithContext(context.Background(), req)
+}
+
+func (s *Service) AddPortForwardingWithContext(ctx context.Context, req *AddPortForwardingRequest) (*iaas.VPCRouter, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.updateSettings(ctx, req.Zone, req.ID, req.MaxRetries, func(current *iaas.VPCRouter) (bool, error) {
+		entries, changed, err := addSettingEntry(current.Settings.PortForwarding, req.PortForwarding, "port forwarding", portForwardingKey, func(a, b *iaas.VPCRouterPortForwarding) bool {
+			return *a == *b
+		})
+		if err != nil || !changed {
+			return false, err
+		}
+		current.Settings.PortForwarding = entries
+		return true, nil
+	})
+}
+
+func portForwardingKey(v *iaas.VPCRouterPortForwarding) string {
+	return fmt.Sprintf("%s/%d", v.Protocol, v.GlobalPort.Int())
+}
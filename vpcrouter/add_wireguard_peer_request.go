@@ -0,0 +1,49 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/packages-go/validate"
+)
+
+// AddWireGuardPeerRequest VPCルータにWireGuardのピアを追加するためのリクエスト
+//
+// 同じ名前のピアが既に存在する場合は更新を行わずに既存のピアを返す
+type AddWireGuardPeerRequest struct {
+	Zone string   `validate:"required"`
+	ID   types.ID `validate:"required"`
+
+	Name string `validate:"required"`
+	// PublicKey ピアの公開鍵、空の場合は鍵ペアをローカルで生成する
+	PublicKey string
+	// IPAddress ピアのアドレス、空の場合はWireGuardのアドレス範囲から空いているアドレスを割り当てる
+	IPAddress string `validate:"omitempty,ipv4"`
+
+	// MaxRetries コンフリクト時の最大リトライ回数、0の場合はserviceutil.DefaultConflictRetryCountを利用する
+	MaxRetries int `validate:"min=0"`
+}
+
+func (req *AddWireGuardPeerRequest) Validate() error {
+	if err := validate.New().Struct(req); err != nil {
+		return err
+	}
+	if req.PublicKey != "" {
+		if _, err := decodeWireGuardKey(req.PublicKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+// WireGuardPeer VPCルータに登録されたWireGuardのピア
+type WireGuardPeer struct {
+	Name      string
+	IPAddress string
+	PublicKey string
+	// PrivateKey ピアの秘密鍵、鍵ペアをローカルで生成した場合のみ設定される
+	PrivateKey string
+}
+
+func (s *Service) AddWireGuardPeer(req *AddWireGuardPeerRequest) (*WireGuardPeer, error) {
+	return s.AddWireGuardPeerWithContext(context.Background(), req)
+}
+
+func (s *Service) AddWireGuardPeerWithContext(ctx context.Context, req *AddWireGuardPeerRequest) (*WireGuardPeer, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var keyPair *WireGuardKeyPair
+	var result *WireGuardPeer
+	_, err := s.updateSettings(ctx, req.Zone, req.ID, req.MaxRetries, func(current *iaas.VPCRouter) (bool, error) {
+		wg := current.Settings.WireGuard
+		if _, _, err := wireGuardPrefix(wg); err != nil {
+			return false, err
+		}
+
+		if peer := findWireGuardPeer(wg, req.Name); peer != nil {
+			if req.PublicKey != "" && req.PublicKey != peer.PublicKey {
+				return false, fmt.Errorf("WireGuard peer %q already exists with a different public key", req.Name)
+			}
+			if req.IPAddress != "" {
+				if addr, err := parsePeerAddress(peer.IPAddress); err != nil || addr.String() != req.IPAddress {
+					return false, fmt.Errorf("WireGuard peer %q already exists with a different IP address: %s", req.Name, peer.IPAddress)
+				}
+			}
+			result = &WireGuardPeer{Name: peer.Name, IPAddress: peer.IPAddress, PublicKey: peer.PublicKey}
+			return false, nil
+		}
+
+		address := req.IPAddress
+		if address == "" {
+			next, err := nextWireGuardPeerAddress(wg)
+			if err != nil {
+				return false, err
+			}
+			address = next
+		} else if err := validateWireGuardPeerAddress(wg, req.Name, address); err != nil {
+			return false, err
+		}
+
+		result = &WireGuardPeer{Name: req.Name, IPAddress: address, PublicKey: req.PublicKey}
+		if result.PublicKey == "" {
+			// リトライ時にも同じ鍵を使うため一度だけ生成する
+			if keyPair == nil {
+				kp, err := GenerateWireGuardKeyPair()
+				if err != nil {
+					return false, err
+				}
+				keyPair = kp
+			}
+			result.PublicKey = keyPair.PublicKey
+			result.PrivateKey = keyPair.PrivateKey
+		}
+
+		wg.Peers = append(wg.Peers, &iaas.VPCRouterWireGuardPeer{
+			Name:      result.Name,
+			IPAddress: result.IPAddress,
+			PublicKey: result.PublicKey,
+		})
+		current.Settings.WireGuardEnabled = true
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -15,6 +15,9 @@
 package vpcrouter
 
 import (
+	"context"
+	"encoding/json"
+
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
 	"github.com/sacloud/iaas-service-go/setup"
@@ -45,6 +48,58 @@ func (req *ApplyRequest) Validate() error {
 	return validate.New().Struct(req)
 }
 
+// UnmarshalJSON NICSetting/AdditionalNICSettingsをPlanIDに応じた型で読み込む
+func (req *ApplyRequest) UnmarshalJSON(data []byte) error {
+	type alias ApplyRequest
+	tmp := &struct {
+		*alias
+		NICSetting            json.RawMessage
+		AdditionalNICSettings []json.RawMessage
+	}{alias: (*alias)(req)}
+	if err := json.Unmarshal(data, tmp); err != nil {
+		return err
+	}
+
+	standard := req.PlanID == types.VPCRouterPlans.Standard
+
+	req.NICSetting = nil
+	if len(tmp.NICSetting) > 0 && string(tmp.NICSetting) != "null" {
+		var nic builder.NICSettingHolder = &builder.PremiumNICSetting{}
+		if standard {
+			nic = &builder.StandardNICSetting{}
+		}
+		if err := json.Unmarshal(tmp.NICSetting, nic); err != nil {
+			return err
+		}
+		req.NICSetting = nic
+	}
+
+	req.AdditionalNICSettings = nil
+	for _, raw := range tmp.AdditionalNICSettings {
+		var nic builder.AdditionalNICSettingHolder = &builder.AdditionalPremiumNICSetting{}
+		if standard {
+			nic = &builder.AdditionalStandardNICSetting{}
+		}
+		if err := json.Unmarshal(raw, nic); err != nil {
+			return err
+		}
+		req.AdditionalNICSettings = append(req.AdditionalNICSettings, nic)
+	}
+	return nil
+}
+
+// applyRequestFromResource 既存のVPCルータのプランに応じてApplyRequestを組み立てる
+func applyRequestFromResource(ctx context.Context, caller iaas.APICaller, zone string, id types.ID) (*ApplyRequest, error) {
+	current, err := iaas.NewVPCRouterOp(caller).Read(ctx, zone, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.PlanID == types.VPCRouterPlans.Standard {
+		return (&UpdateStandardRequest{Zone: zone, ID: id}).ApplyRequest(ctx, caller)
+	}
+	return (&UpdateRequest{Zone: zone, ID: id}).ApplyRequest(ctx, caller)
+}
+
 // RouterSetting VPCルータの設定
 type RouterSetting struct {
 	VRID                      int
@@ -0,0 +1,88 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"testing"
+
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/serviceutil"
+	vpcRouterBuilder "github.com/sacloud/iaas-service-go/vpcrouter/builder"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyRequest_UnmarshalJSON(t *testing.T) {
+	cases := []struct {
+		name string
+		in   *ApplyRequest
+	}{
+		{
+			name: "standard",
+			in: &ApplyRequest{
+				Zone:       "is1a",
+				ID:         types.ID(123456789012),
+				Name:       "standard",
+				PlanID:     types.VPCRouterPlans.Standard,
+				NICSetting: &vpcRouterBuilder.StandardNICSetting{},
+				AdditionalNICSettings: []vpcRouterBuilder.AdditionalNICSettingHolder{
+					&vpcRouterBuilder.AdditionalStandardNICSetting{
+						SwitchID:       types.ID(123456789013),
+						IPAddress:      "192.168.0.1",
+						NetworkMaskLen: 24,
+						Index:          1,
+					},
+				},
+				RouterSetting: &RouterSetting{VRID: 1},
+			},
+		},
+		{
+			name: "premium",
+			in: &ApplyRequest{
+				Zone:   "is1a",
+				ID:     types.ID(123456789012),
+				Name:   "premium",
+				PlanID: types.VPCRouterPlans.Premium,
+				NICSetting: &vpcRouterBuilder.PremiumNICSetting{
+					SwitchID:         types.ID(123456789013),
+					IPAddresses:      []string{"192.0.2.11", "192.0.2.12"},
+					VirtualIPAddress: "192.0.2.10",
+				},
+				AdditionalNICSettings: []vpcRouterBuilder.AdditionalNICSettingHolder{
+					&vpcRouterBuilder.AdditionalPremiumNICSetting{
+						SwitchID:         types.ID(123456789014),
+						IPAddresses:      []string{"192.168.0.11", "192.168.0.12"},
+						VirtualIPAddress: "192.168.0.10",
+						NetworkMaskLen:   24,
+						Index:            1,
+					},
+				},
+				RouterSetting: &RouterSetting{VRID: 1},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, format := range []string{serviceutil.FormatJSON, serviceutil.FormatYAML} {
+				data, err := serviceutil.MarshalRequest(tc.in, format)
+				require.NoError(t, err)
+
+				got := &ApplyRequest{}
+				require.NoError(t, serviceutil.UnmarshalRequest(data, format, got))
+				require.Equal(t, tc.in, got)
+			}
+		})
+	}
+}
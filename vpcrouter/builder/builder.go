@@ -18,7 +18,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/accessor"
@@ -226,7 +225,9 @@ func (b *Builder) create(ctx context.Context, zone string) (*iaas.VPCRouter, err
 			}
 
 			// [HACK] スイッチ接続直後だとエラーになることがあるため数秒待つ
-			time.Sleep(b.SetupOptions.NICUpdateWaitDuration)
+			if err := setup2.Sleep(ctx, b.SetupOptions.NICUpdateWaitDuration); err != nil {
+				return err
+			}
 
 			// 残りの設定の投入
 			_, err := b.Client.UpdateSettings(ctx, zone, id, &iaas.VPCRouterUpdateSettingsRequest{
@@ -357,7 +358,9 @@ func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.V
 		}
 	}
 	// [HACK] スイッチ接続直後だとエラーになることがあるため数秒待つ
-	time.Sleep(b.SetupOptions.NICUpdateWaitDuration)
+	if err := setup2.Sleep(ctx, b.SetupOptions.NICUpdateWaitDuration); err != nil {
+		return nil, err
+	}
 
 	_, err = b.Client.Update(ctx, zone, id, &iaas.VPCRouterUpdateRequest{
 		Name:        b.Name,
@@ -0,0 +1,225 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// DefaultDHCPStaticMappingTagPrefix サーバのタグからDHCPで割り当てるアドレスを指定する際のタグのプレフィックス
+//
+// 例: dhcp-ip=192.168.0.11
+const DefaultDHCPStaticMappingTagPrefix = "dhcp-ip="
+
+// DHCPStaticMappingPolicy 接続されたサーバのNICに割り当てるアドレスを決定するためのポリシー
+type DHCPStaticMappingPolicy struct {
+	// Addresses サーバのホスト名または名前をキーとした割り当てるアドレス
+	Addresses map[string]string
+	// TagPrefix Addressesで決定できない場合に参照するタグのプレフィックス、空の場合はDefaultDHCPStaticMappingTagPrefix
+	TagPrefix string
+}
+
+func (p *DHCPStaticMappingPolicy) address(server *iaas.Server) string {
+	for _, key := range []string{server.HostName, server.Name} {
+		if key == "" {
+			continue
+		}
+		if address, ok := p.Addresses[key]; ok {
+			return address
+		}
+	}
+
+	prefix := p.TagPrefix
+	if prefix == "" {
+		prefix = DefaultDHCPStaticMappingTagPrefix
+	}
+	for _, tag := range server.Tags {
+		if strings.HasPrefix(tag, prefix) {
+			return strings.TrimPrefix(tag, prefix)
+		}
+	}
+	return ""
+}
+
+// DHCPStaticMappingSkipped アドレスを割り当てられなかったサーバのNIC
+type DHCPStaticMappingSkipped struct {
+	ServerID   types.ID
+	ServerName string
+	MACAddress string
+	Reason     string
+}
+
+// DHCPStaticMappingSyncResult DHCPスタティックマッピングの同期結果
+type DHCPStaticMappingSyncResult struct {
+	// Mappings 同期後(DryRunの場合は同期予定)の全てのDHCPスタティックマッピング
+	Mappings []*iaas.VPCRouterDHCPStaticMapping
+	Added    []*iaas.VPCRouterDHCPStaticMapping
+	Updated  []*iaas.VPCRouterDHCPStaticMapping
+	Removed  []*iaas.VPCRouterDHCPStaticMapping
+	Skipped  []*DHCPStaticMappingSkipped
+}
+
+// Changed 同期によってDHCPスタティックマッピングが変更されるか
+func (r *DHCPStaticMappingSyncResult) Changed() bool {
+	return len(r.Added) > 0 || len(r.Updated) > 0 || len(r.Removed) > 0
+}
+
+// dhcpInterface VPCルータのインターフェースのネットワークと、VPCルータ自身が利用するアドレスを返す
+func dhcpInterface(vpcRouter *iaas.VPCRouter, index int) (netip.Prefix, map[netip.Addr]bool, error) {
+	if vpcRouter.Settings != nil {
+		for _, iface := range vpcRouter.Settings.Interfaces {
+			if iface.Index != index || len(iface.IPAddress) == 0 {
+				continue
+			}
+			routerAddresses := map[netip.Addr]bool{}
+			for _, address := range append([]string{iface.VirtualIPAddress}, iface.IPAddress...) {
+				if addr, err := netip.ParseAddr(address); err == nil {
+					routerAddresses[addr] = true
+				}
+			}
+			addr, err := netip.ParseAddr(iface.IPAddress[0])
+			if err != nil {
+				return netip.Prefix{}, nil, fmt.Errorf("invalid IP address of interface %d: %w", index, err)
+			}
+			network, err := addr.Prefix(iface.NetworkMaskLen)
+			if err != nil {
+				return netip.Prefix{}, nil, fmt.Errorf("invalid network mask length of interface %d: %w", index, err)
+			}
+			return network, routerAddresses, nil
+		}
+	}
+	return netip.Prefix{}, nil, fmt.Errorf("interface %d is not configured on the VPC router", index)
+}
+
+// connectedSwitchID VPCルータのインターフェースに接続されたスイッチのIDを返す
+func connectedSwitchID(vpcRouter *iaas.VPCRouter, index int) (types.ID, error) {
+	for _, iface := range vpcRouter.Interfaces {
+		if iface.Index == index && !iface.SwitchID.IsEmpty() {
+			return iface.SwitchID, nil
+		}
+	}
+	return types.ID(0), fmt.Errorf("no switch is connected to interface %d", index)
+}
+
+// planDHCPStaticMappings 現在のDHCPスタティックマッピングとスイッチに接続されたサーバから同期後のマッピングを算出する
+//
+// pruneが指定された場合、インターフェースのネットワーク内のマッピングのうち対象サーバのNICに該当しないものを削除する
+func planDHCPStaticMappings(
+	current []*iaas.VPCRouterDHCPStaticMapping,
+	servers []*iaas.Server,
+	switchID types.ID,
+	network netip.Prefix,
+	routerAddresses map[netip.Addr]bool,
+	policy *DHCPStaticMappingPolicy,
+	prune bool,
+) *DHCPStaticMappingSyncResult {
+	result := &DHCPStaticMappingSyncResult{}
+
+	type desiredMapping struct {
+		server  *iaas.Server
+		mapping *iaas.VPCRouterDHCPStaticMapping
+	}
+	var desired []desiredMapping
+	desiredMACs := map[string]bool{}
+	for _, server := range servers {
+		for _, nic := range server.Interfaces {
+			if nic.SwitchID != switchID || nic.MACAddress == "" {
+				continue
+			}
+			skip := func(reason string) {
+				result.Skipped = append(result.Skipped, &DHCPStaticMappingSkipped{
+					ServerID:   server.ID,
+					ServerName: server.Name,
+					MACAddress: nic.MACAddress,
+					Reason:     reason,
+				})
+			}
+
+			address := policy.address(server)
+			if address == "" {
+				skip("no address is assigned by the policy")
+				continue
+			}
+			addr, err := netip.ParseAddr(address)
+			if err != nil {
+				skip(fmt.Sprintf("invalid address %q", address))
+				continue
+			}
+			if !network.Contains(addr) {
+				skip(fmt.Sprintf("address %s is out of network %s", addr, network))
+				continue
+			}
+			if routerAddresses[addr] {
+				skip(fmt.Sprintf("address %s is used by the VPC router", addr))
+				continue
+			}
+
+			mapping := &iaas.VPCRouterDHCPStaticMapping{MACAddress: strings.ToLower(nic.MACAddress), IPAddress: addr.String()}
+			desired = append(desired, desiredMapping{server: server, mapping: mapping})
+			desiredMACs[dhcpStaticMappingKey(mapping)] = true
+		}
+	}
+
+	var mappings []*iaas.VPCRouterDHCPStaticMapping
+	for _, m := range current {
+		if prune && !desiredMACs[dhcpStaticMappingKey(m)] {
+			if addr, err := netip.ParseAddr(m.IPAddress); err == nil && network.Contains(addr) {
+				result.Removed = append(result.Removed, m)
+				continue
+			}
+		}
+		copied := *m
+		mappings = append(mappings, &copied)
+	}
+
+	for _, d := range desired {
+		var existing *iaas.VPCRouterDHCPStaticMapping
+		var conflict *iaas.VPCRouterDHCPStaticMapping
+		for _, m := range mappings {
+			switch {
+			case dhcpStaticMappingKey(m) == dhcpStaticMappingKey(d.mapping):
+				existing = m
+			case m.IPAddress == d.mapping.IPAddress:
+				conflict = m
+			}
+		}
+		if conflict != nil {
+			result.Skipped = append(result.Skipped, &DHCPStaticMappingSkipped{
+				ServerID:   d.server.ID,
+				ServerName: d.server.Name,
+				MACAddress: d.mapping.MACAddress,
+				Reason:     fmt.Sprintf("address %s is already mapped to %s", conflict.IPAddress, conflict.MACAddress),
+			})
+			continue
+		}
+
+		switch {
+		case existing == nil:
+			mappings = append(mappings, d.mapping)
+			result.Added = append(result.Added, d.mapping)
+		case existing.IPAddress != d.mapping.IPAddress:
+			existing.IPAddress = d.mapping.IPAddress
+			result.Updated = append(result.Updated, existing)
+		}
+	}
+
+	result.Mappings = mappings
+	return result
+}
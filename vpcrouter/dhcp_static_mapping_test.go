@@ -0,0 +1,110 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDHCPInterface(t *testing.T) {
+	vpcRouter := &iaas.VPCRouter{
+		Settings: &iaas.VPCRouterSetting{
+			Interfaces: []*iaas.VPCRouterInterfaceSetting{
+				{Index: 1, VirtualIPAddress: "192.168.0.1", IPAddress: []string{"192.168.0.2", "192.168.0.3"}, NetworkMaskLen: 24},
+			},
+		},
+	}
+	network, routerAddresses, err := dhcpInterface(vpcRouter, 1)
+	require.NoError(t, err)
+	require.Equal(t, netip.MustParsePrefix("192.168.0.0/24"), network)
+	require.Len(t, routerAddresses, 3)
+
+	_, _, err = dhcpInterface(vpcRouter, 2)
+	require.Error(t, err)
+}
+
+func TestPlanDHCPStaticMappings(t *testing.T) {
+	switchID := types.ID(123456789012)
+	network := netip.MustParsePrefix("192.168.0.0/24")
+	routerAddresses := map[netip.Addr]bool{netip.MustParseAddr("192.168.0.1"): true}
+
+	server := func(id types.ID, name string, tags []string, macs ...string) *iaas.Server {
+		s := &iaas.Server{ID: id, Name: name, Tags: tags}
+		for _, mac := range macs {
+			s.Interfaces = append(s.Interfaces, &iaas.InterfaceView{SwitchID: switchID, MACAddress: mac})
+		}
+		return s
+	}
+	servers := []*iaas.Server{
+		server(1, "web1", nil, "9C:A3:BA:00:00:01"),
+		server(2, "web2", []string{"dhcp-ip=192.168.0.12"}, "9c:a3:ba:00:00:02"),
+		server(3, "db", nil, "9c:a3:ba:00:00:03"),
+		server(4, "out-of-range", []string{"dhcp-ip=10.0.0.1"}, "9c:a3:ba:00:00:04"),
+		server(5, "router", []string{"dhcp-ip=192.168.0.1"}, "9c:a3:ba:00:00:05"),
+		server(6, "conflict", []string{"dhcp-ip=192.168.0.100"}, "9c:a3:ba:00:00:06"),
+	}
+	policy := &DHCPStaticMappingPolicy{Addresses: map[string]string{"web1": "192.168.0.11"}}
+	current := []*iaas.VPCRouterDHCPStaticMapping{
+		{MACAddress: "9c:a3:ba:00:00:02", IPAddress: "192.168.0.20"},
+		{MACAddress: "9c:a3:ba:00:00:99", IPAddress: "192.168.0.100"},
+		{MACAddress: "9c:a3:ba:00:00:98", IPAddress: "10.0.0.100"},
+	}
+
+	t.Run("reconcile", func(t *testing.T) {
+		result := planDHCPStaticMappings(current, servers, switchID, network, routerAddresses, policy, false)
+		require.True(t, result.Changed())
+		require.Equal(t, []*iaas.VPCRouterDHCPStaticMapping{
+			{MACAddress: "9c:a3:ba:00:00:01", IPAddress: "192.168.0.11"},
+		}, result.Added)
+		require.Equal(t, []*iaas.VPCRouterDHCPStaticMapping{
+			{MACAddress: "9c:a3:ba:00:00:02", IPAddress: "192.168.0.12"},
+		}, result.Updated)
+		require.Empty(t, result.Removed)
+		require.Len(t, result.Mappings, 4)
+
+		var skipped []types.ID
+		for _, s := range result.Skipped {
+			skipped = append(skipped, s.ServerID)
+		}
+		require.Equal(t, []types.ID{3, 4, 5, 6}, skipped)
+
+		// 元のマッピングは変更しない
+		require.Equal(t, "192.168.0.20", current[0].IPAddress)
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		result := planDHCPStaticMappings(current, servers, switchID, network, routerAddresses, policy, true)
+		require.Equal(t, []*iaas.VPCRouterDHCPStaticMapping{
+			{MACAddress: "9c:a3:ba:00:00:99", IPAddress: "192.168.0.100"},
+		}, result.Removed)
+		require.Equal(t, []*iaas.VPCRouterDHCPStaticMapping{
+			{MACAddress: "9c:a3:ba:00:00:02", IPAddress: "192.168.0.12"},
+			{MACAddress: "9c:a3:ba:00:00:98", IPAddress: "10.0.0.100"},
+			{MACAddress: "9c:a3:ba:00:00:01", IPAddress: "192.168.0.11"},
+			{MACAddress: "9c:a3:ba:00:00:06", IPAddress: "192.168.0.100"},
+		}, result.Mappings)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		first := planDHCPStaticMappings(current, servers, switchID, network, routerAddresses, policy, false)
+		second := planDHCPStaticMappings(first.Mappings, servers, switchID, network, routerAddresses, policy, false)
+		require.False(t, second.Changed())
+	})
+}
@@ -0,0 +1,68 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-service-go/serviceutil"
+)
+
+// Export 既存リソースをIDを保持したApplyRequestとしてJSONまたはYAML形式で出力する
+func (s *Service) Export(req *ExportRequest) ([]byte, error) {
+	return s.ExportWithContext(context.Background(), req)
+}
+
+// ExportWithContext 既存リソースをIDを保持したApplyRequestとしてJSONまたはYAML形式で出力する
+func (s *Service) ExportWithContext(ctx context.Context, req *ExportRequest) ([]byte, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	applyRequest, err := applyRequestFromResource(ctx, s.caller, req.Zone, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !req.IncludeSecrets {
+		redactSecrets(applyRequest)
+	}
+	return serviceutil.MarshalRequest(applyRequest, req.Format)
+}
+
+// redactSecrets 事前共有鍵、リモートアクセスユーザのパスワード、WireGuardピアの鍵を空にする
+//
+// これらを空にしたApplyRequestを再適用する場合は値を設定する必要がある
+func redactSecrets(req *ApplyRequest) {
+	setting := req.RouterSetting
+	if setting == nil {
+		return
+	}
+	if setting.L2TPIPsecServer != nil {
+		setting.L2TPIPsecServer.PreSharedSecret = ""
+	}
+	if setting.SiteToSiteIPsecVPN != nil {
+		for _, config := range setting.SiteToSiteIPsecVPN.Config {
+			config.PreSharedSecret = ""
+		}
+	}
+	for _, user := range setting.RemoteAccessUsers {
+		user.Password = ""
+	}
+	if setting.WireGuard != nil {
+		for _, peer := range setting.WireGuard.Peers {
+			peer.PublicKey = ""
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/packages-go/validate"
+)
+
+// サイト間VPNの対向機器向け設定の出力形式
+const (
+	SiteToSiteVPNFormatSwanctl = "swanctl"
+	SiteToSiteVPNFormatSummary = "summary"
+)
+
+// ExportSiteToSiteVPNRequest VPCルータのサイト間VPN設定から対向機器側の設定を出力するためのリクエスト
+type ExportSiteToSiteVPNRequest struct {
+	Zone string   `validate:"required"`
+	ID   types.ID `validate:"required"`
+
+	// Peer 対向機器のアドレス、空の場合は全ての対向機器の設定を出力する
+	Peer string
+	// Format 出力形式、空の場合はSiteToSiteVPNFormatSummaryとなる
+	Format string `validate:"omitempty,oneof=swanctl summary"`
+}
+
+func (req *ExportSiteToSiteVPNRequest) Validate() error {
+	return validate.New().Struct(req)
+}
@@ -0,0 +1,51 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import "context"
+
+func (s *Service) ExportSiteToSiteVPN(req *ExportSiteToSiteVPNRequest) ([]byte, error) {
+	return s.ExportSiteToSiteVPNWithContext(context.Background(), req)
+}
+
+func (s *Service) ExportSiteToSiteVPNWithContext(ctx context.Context, req *ExportSiteToSiteVPNRequest) ([]byte, error) {
+	configs, err := s.SiteToSiteVPNPeerConfigsWithContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Format == SiteToSiteVPNFormatSwanctl {
+		return []byte(FormatSwanctl(configs)), nil
+	}
+	return []byte(FormatSiteToSiteVPNSummary(configs)), nil
+}
+
+// SiteToSiteVPNPeerConfigs VPCルータのサイト間VPN設定から対向機器側の設定を組み立てる
+func (s *Service) SiteToSiteVPNPeerConfigs(req *ExportSiteToSiteVPNRequest) ([]*SiteToSiteVPNPeerConfig, error) {
+	return s.SiteToSiteVPNPeerConfigsWithContext(context.Background(), req)
+}
+
+// SiteToSiteVPNPeerConfigsWithContext VPCルータのサイト間VPN設定から対向機器側の設定を組み立てる
+func (s *Service) SiteToSiteVPNPeerConfigsWithContext(ctx context.Context, req *ExportSiteToSiteVPNRequest) ([]*SiteToSiteVPNPeerConfig, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	vpcRouter, err := s.ReadWithContext(ctx, &ReadRequest{Zone: req.Zone, ID: req.ID})
+	if err != nil {
+		return nil, err
+	}
+	return siteToSiteVPNPeerConfigs(vpcRouter, req.Peer)
+}
@@ -0,0 +1,62 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactSecrets(t *testing.T) {
+	req := &ApplyRequest{
+		RouterSetting: &RouterSetting{
+			L2TPIPsecServer: &iaas.VPCRouterL2TPIPsecServer{
+				RangeStart:      "192.168.0.250",
+				RangeStop:       "192.168.0.254",
+				PreSharedSecret: "l2tp-secret",
+			},
+			SiteToSiteIPsecVPN: &iaas.VPCRouterSiteToSiteIPsecVPN{
+				Config: []*iaas.VPCRouterSiteToSiteIPsecVPNConfig{
+					{Peer: "198.51.100.1", PreSharedSecret: "s2s-secret"},
+				},
+			},
+			RemoteAccessUsers: []*iaas.VPCRouterRemoteAccessUser{
+				{UserName: "user1", Password: "password"},
+			},
+			WireGuard: &iaas.VPCRouterWireGuard{
+				IPAddress: "192.168.31.1/24",
+				Peers: []*iaas.VPCRouterWireGuardPeer{
+					{Name: "peer1", IPAddress: "192.168.31.11", PublicKey: "public-key"},
+				},
+			},
+		},
+	}
+	redactSecrets(req)
+
+	setting := req.RouterSetting
+	require.Equal(t, "192.168.0.250", setting.L2TPIPsecServer.RangeStart)
+	require.Empty(t, setting.L2TPIPsecServer.PreSharedSecret)
+	require.Equal(t, "198.51.100.1", setting.SiteToSiteIPsecVPN.Config[0].Peer)
+	require.Empty(t, setting.SiteToSiteIPsecVPN.Config[0].PreSharedSecret)
+	require.Equal(t, "user1", setting.RemoteAccessUsers[0].UserName)
+	require.Empty(t, setting.RemoteAccessUsers[0].Password)
+	require.Equal(t, "peer1", setting.WireGuard.Peers[0].Name)
+	require.Empty(t, setting.WireGuard.Peers[0].PublicKey)
+
+	// ルータの設定が無い場合も安全に呼び出せる
+	redactSecrets(&ApplyRequest{})
+}
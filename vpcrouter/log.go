@@ -0,0 +1,178 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"strings"
+	"time"
+)
+
+// LogKind VPCルータのログの種別
+type LogKind string
+
+// LogKinds VPCルータのログの種別
+var LogKinds = struct {
+	IPsec     LogKind
+	L2TP      LogKind
+	WireGuard LogKind
+	Firewall  LogKind
+	Other     LogKind
+}{
+	IPsec:     "ipsec",
+	L2TP:      "l2tp",
+	WireGuard: "wireguard",
+	Firewall:  "firewall",
+	Other:     "other",
+}
+
+// LogEntry VPCルータのログの1行
+type LogEntry struct {
+	// Time ログの時刻、パースできなかった場合はゼロ値
+	Time    time.Time
+	Kind    LogKind
+	Message string
+}
+
+// DefaultLogLocation syslog形式のログの時刻を解釈する際のデフォルトのタイムゾーン
+//
+// VPCルータはログを日本時間で出力する
+var DefaultLogLocation = time.FixedZone("JST", 9*60*60)
+
+// LogFilter ログの絞り込み条件、ゼロ値の条件は無視される
+type LogFilter struct {
+	// From この時刻以降のログのみを対象とする
+	From time.Time
+	// To この時刻より前のログのみを対象とする
+	To time.Time
+	// Kinds いずれかの種別に該当するログのみを対象とする
+	Kinds []LogKind `validate:"omitempty,dive,oneof=ipsec l2tp wireguard firewall other"`
+	// Contains 大文字小文字を区別せずにこの文字列を含むログのみを対象とする
+	Contains string
+	// Location タイムゾーンを含まないsyslog形式の時刻を解釈するタイムゾーン、省略時はDefaultLogLocation
+	Location *time.Location
+}
+
+func (f *LogFilter) location() *time.Location {
+	if f.Location != nil {
+		return f.Location
+	}
+	return DefaultLogLocation
+}
+
+// Match ログが絞り込み条件に該当するか
+//
+// 時刻での絞り込みが指定された場合、時刻をパースできなかったログは該当しないものとする
+func (f *LogFilter) Match(entry *LogEntry) bool {
+	if !f.From.IsZero() || !f.To.IsZero() {
+		if entry.Time.IsZero() {
+			return false
+		}
+		if !f.From.IsZero() && entry.Time.Before(f.From) {
+			return false
+		}
+		if !f.To.IsZero() && !entry.Time.Before(f.To) {
+			return false
+		}
+	}
+	if len(f.Kinds) > 0 {
+		found := false
+		for _, kind := range f.Kinds {
+			if kind == entry.Kind {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return containsFold(entry.Message, f.Contains)
+}
+
+// Filter 絞り込み条件に該当するログのみを返す
+func (f *LogFilter) Filter(entries []*LogEntry) []*LogEntry {
+	var results []*LogEntry
+	for _, entry := range entries {
+		if f.Match(entry) {
+			results = append(results, entry)
+		}
+	}
+	return results
+}
+
+// parseLogs 改行区切りのログをパースする
+//
+// syslog形式の時刻はlocのタイムゾーンで解釈する。
+// また年が含まれないため、nowの年を補完する。補完した結果がnowより1日以上未来になる場合は前年とみなす
+func parseLogs(lines []string, kind LogKind, now time.Time, loc *time.Location) []*LogEntry {
+	var entries []*LogEntry
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		entryKind := kind
+		if entryKind == "" {
+			entryKind = classifyLog(line)
+		}
+		entries = append(entries, &LogEntry{
+			Time:    parseLogTime(line, now, loc),
+			Kind:    entryKind,
+			Message: line,
+		})
+	}
+	return entries
+}
+
+func parseLogTime(line string, now time.Time, loc *time.Location) time.Time {
+	if fields := strings.Fields(line); len(fields) > 0 {
+		if t, err := time.Parse(time.RFC3339, fields[0]); err == nil {
+			return t
+		}
+	}
+	if len(line) < len(time.Stamp) {
+		return time.Time{}
+	}
+	t, err := time.ParseInLocation(time.Stamp, line[:len(time.Stamp)], loc)
+	if err != nil {
+		return time.Time{}
+	}
+	year := now.In(loc).Year()
+	if time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc).After(now.Add(24 * time.Hour)) {
+		year--
+	}
+	return time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc)
+}
+
+// classifyLog ログの出力元からログの種別を判定する
+func classifyLog(line string) LogKind {
+	if strings.Contains(line, "IN=") && strings.Contains(line, "OUT=") {
+		return LogKinds.Firewall
+	}
+	lower := strings.ToLower(line)
+	switch {
+	case strings.Contains(lower, "wireguard"), strings.Contains(lower, "wg0"):
+		return LogKinds.WireGuard
+	case strings.Contains(lower, "xl2tpd"), strings.Contains(lower, "l2tp"), strings.Contains(lower, "pppd"):
+		return LogKinds.L2TP
+	case strings.Contains(lower, "charon"), strings.Contains(lower, "ipsec"), strings.Contains(lower, "pluto"):
+		return LogKinds.IPsec
+	}
+	return LogKinds.Other
+}
+
+func containsFold(s, substr string) bool {
+	return substr == "" || strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
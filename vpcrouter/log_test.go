@@ -0,0 +1,186 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogLines = []string{
+	"Jan 11 10:26:16 localhost charon: 16[IKE] IKE_SA peer1[1] established between 203.0.113.10[203.0.113.10]...198.51.100.1[198.51.100.1]",
+	"Jan 11 10:27:00 localhost xl2tpd[1234]: Connection established to 198.51.100.2, 1701.",
+	"Jan 11 10:28:00 localhost kernel: wireguard: wg0: Receiving handshake initiation from peer 1",
+	"Jan 11 10:29:00 localhost kernel: [12345.678901] IN=eth0 OUT= SRC=198.51.100.3 DST=203.0.113.10 PROTO=TCP DPT=22",
+	"Jan 11 10:30:00 localhost dnsmasq-dhcp[567]: DHCPACK(eth1) 192.168.0.11 9c:a3:ba:00:00:01",
+	"",
+	"invalid line",
+}
+
+func TestParseLogs(t *testing.T) {
+	now := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	entries := parseLogs(testLogLines, "", now, time.UTC)
+	require.Len(t, entries, 6)
+
+	var kinds []LogKind
+	for _, entry := range entries {
+		kinds = append(kinds, entry.Kind)
+	}
+	require.Equal(t, []LogKind{
+		LogKinds.IPsec,
+		LogKinds.L2TP,
+		LogKinds.WireGuard,
+		LogKinds.Firewall,
+		LogKinds.Other,
+		LogKinds.Other,
+	}, kinds)
+
+	require.Equal(t, time.Date(2026, 1, 11, 10, 26, 16, 0, time.UTC), entries[0].Time)
+	require.True(t, entries[5].Time.IsZero())
+}
+
+func TestParseLogTime(t *testing.T) {
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	// 年をまたぐ場合は前年とみなす
+	require.Equal(t, time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC), parseLogTime("Dec 31 23:59:59 localhost charon: test", now, time.UTC))
+	require.Equal(t, time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC), parseLogTime("Jan  1 08:00:00 localhost charon: test", now, time.UTC))
+	require.Equal(t, time.Date(2026, 1, 1, 8, 0, 0, 0, time.FixedZone("", 9*60*60)), parseLogTime("2026-01-01T08:00:00+09:00 localhost charon: test", now, time.UTC))
+	require.True(t, parseLogTime("short", now, time.UTC).IsZero())
+
+	// syslog形式の時刻はnowのタイムゾーンではなく指定したタイムゾーンで解釈する
+	now = time.Date(2026, 1, 1, 0, 30, 0, 0, time.UTC)
+	parsed := parseLogTime("Jan  1 09:00:00 localhost charon: test", now, DefaultLogLocation)
+	require.Equal(t, time.Date(2026, 1, 1, 9, 0, 0, 0, DefaultLogLocation), parsed)
+	require.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), parsed.UTC())
+}
+
+func TestLogFilter(t *testing.T) {
+	now := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	entries := parseLogs(testLogLines, "", now, time.UTC)
+
+	cases := []struct {
+		name   string
+		filter *LogFilter
+		want   int
+	}{
+		{
+			name:   "empty",
+			filter: &LogFilter{},
+			want:   6,
+		},
+		{
+			name: "time range",
+			filter: &LogFilter{
+				From: time.Date(2026, 1, 11, 10, 27, 0, 0, time.UTC),
+				To:   time.Date(2026, 1, 11, 10, 29, 0, 0, time.UTC),
+			},
+			want: 2,
+		},
+		{
+			name:   "kinds",
+			filter: &LogFilter{Kinds: []LogKind{LogKinds.IPsec, LogKinds.Firewall}},
+			want:   2,
+		},
+		{
+			name:   "contains",
+			filter: &LogFilter{Contains: "198.51.100"},
+			want:   3,
+		},
+		{
+			name:   "combined",
+			filter: &LogFilter{Kinds: []LogKind{LogKinds.L2TP}, Contains: "CONNECTION"},
+			want:   1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Len(t, tc.filter.Filter(entries), tc.want)
+		})
+	}
+}
+
+func TestLogFilter_Location(t *testing.T) {
+	now := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	// 10:27:00と10:28:00(日本時間)のログが対象となる
+	from := time.Date(2026, 1, 11, 1, 27, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 11, 1, 29, 0, 0, time.UTC)
+
+	filter := &LogFilter{From: from, To: to}
+	require.Len(t, filter.Filter(parseLogs(testLogLines, "", now, filter.location())), 2)
+
+	// 指定したタイムゾーンで解釈した場合は対象外となる
+	filter = &LogFilter{From: from, To: to, Location: time.UTC}
+	require.Empty(t, filter.Filter(parseLogs(testLogLines, "", now, filter.location())))
+}
+
+func TestLogsRequest_Validate(t *testing.T) {
+	req := &LogsRequest{Zone: "is1a", ID: 1, LogFilter: LogFilter{Kinds: []LogKind{LogKinds.IPsec}}}
+	require.NoError(t, req.Validate())
+
+	req.Kinds = []LogKind{"invalid"}
+	require.Error(t, req.Validate())
+}
+
+func TestFilterStatus(t *testing.T) {
+	now := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	status := &iaas.VPCRouterStatus{
+		SessionCount: 10,
+		WireGuard:    &iaas.WireGuardStatus{PublicKey: "server-public-key"},
+		DHCPServerLeases: []*iaas.VPCRouterDHCPServerLease{
+			{IPAddress: "192.168.0.11", MACAddress: "9c:a3:ba:00:00:01"},
+			{IPAddress: "192.168.0.12", MACAddress: "9c:a3:ba:00:00:02"},
+		},
+		L2TPIPsecServerSessions: []*iaas.VPCRouterL2TPIPsecServerSession{
+			{User: "user1", IPAddress: "192.168.0.101", TimeSec: 60},
+		},
+		SiteToSiteIPsecVPNPeers: []*iaas.VPCRouterSiteToSiteIPsecVPNPeer{
+			{Peer: "198.51.100.1", Status: "UP"},
+		},
+		VPNLogs:             testLogLines[:2],
+		FirewallReceiveLogs: []string{"Jan 11 10:29:00 localhost kernel: dropped from 192.168.0.11"},
+	}
+	peers := []*iaas.VPCRouterWireGuardPeer{
+		{Name: "peer1", IPAddress: "192.168.0.11", PublicKey: "peer1-public-key"},
+		{Name: "peer2", IPAddress: "192.168.0.12", PublicKey: "peer2-public-key"},
+	}
+
+	result := filterStatus(status, peers, &LogFilter{}, now)
+	require.Equal(t, 10, result.SessionCount)
+	require.Equal(t, "server-public-key", result.WireGuardPublicKey)
+	require.Len(t, result.DHCPServerLeases, 2)
+	require.Len(t, result.L2TPIPsecServerSessions, 1)
+	require.Len(t, result.SiteToSiteIPsecVPNPeers, 1)
+	require.Len(t, result.WireGuardPeers, 2)
+	require.Len(t, result.Logs, 3)
+	require.Equal(t, LogKinds.Firewall, result.Logs[2].Kind)
+	require.Equal(t, time.Date(2026, 1, 11, 10, 26, 16, 0, DefaultLogLocation), result.Logs[0].Time)
+
+	result = filterStatus(status, peers, &LogFilter{Location: time.UTC}, now)
+	require.Equal(t, time.Date(2026, 1, 11, 10, 26, 16, 0, time.UTC), result.Logs[0].Time)
+
+	result = filterStatus(status, peers, &LogFilter{Contains: "192.168.0.11"}, now)
+	require.Len(t, result.DHCPServerLeases, 1)
+	require.Empty(t, result.L2TPIPsecServerSessions)
+	require.Empty(t, result.SiteToSiteIPsecVPNPeers)
+	require.Equal(t, peers[:1], result.WireGuardPeers)
+	require.Len(t, result.Logs, 1)
+
+	result = filterStatus(status, peers, &LogFilter{Contains: "PEER2"}, now)
+	require.Equal(t, peers[1:], result.WireGuardPeers)
+}
@@ -0,0 +1,40 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+func (s *Service) Logs(req *LogsRequest) ([]*LogEntry, error) {
+	return s.LogsWithContext(context.Background(), req)
+}
+
+func (s *Service) LogsWithContext(ctx context.Context, req *LogsRequest) ([]*LogEntry, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	logs, err := iaas.NewVPCRouterOp(s.caller).Logs(ctx, req.Zone, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	entries := parseLogs(strings.Split(logs.Log, "\n"), "", time.Now(), req.location())
+	return req.Filter(entries), nil
+}
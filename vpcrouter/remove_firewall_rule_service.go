@@ -0,0 +1,45 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"context"
+	"slices"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+func (s *Service) RemoveFirewallRule(req *RemoveFirewallRuleRequest) (*iaas.VPCRouter, error) {
+	return s.RemoveFirewallRuleWithContext(context.Background(), req)
+}
+
+func (s *Service) RemoveFirewallRuleWithContext(ctx context.Context, req *RemoveFirewallRuleRequest) (*iaas.VPCRouter, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.updateSettings(ctx, req.Zone, req.ID, req.MaxRetries, func(current *iaas.VPCRouter) (bool, error) {
+		firewall := findFirewall(current.Settings, req.Index)
+		if firewall == nil {
+			return false, nil
+		}
+		rules := firewallRules(firewall, req.Direction)
+		before := len(*rules)
+		*rules = slices.DeleteFunc(*rules, func(rule *iaas.VPCRouterFirewallRule) bool {
+			return *rule == *req.Rule
+		})
+		return len(*rules) != before, nil
+	})
+}
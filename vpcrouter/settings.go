@@ -0,0 +1,105 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/serviceutil"
+)
+
+// settingsMutator 現在のVPCルータの設定に変更を加え、変更があったかを返す
+type settingsMutator func(current *iaas.VPCRouter) (changed bool, err error)
+
+// settingsHandler updateSettingsで利用するVPCルータのAPI
+type settingsHandler interface {
+	Read(ctx context.Context, zone string, id types.ID) (*iaas.VPCRouter, error)
+	UpdateSettings(ctx context.Context, zone string, id types.ID, param *iaas.VPCRouterUpdateSettingsRequest) (*iaas.VPCRouter, error)
+	Config(ctx context.Context, zone string, id types.ID) error
+}
+
+// updateSettings 現在のVPCルータを読み込みmutatorで設定を変更した後、SettingsHashを指定して更新し設定を反映する
+//
+// コンフリクトした場合は読み込みからやり直す。mutatorが変更なしと判定した場合は更新を行わず現在のVPCルータを返す。
+func (s *Service) updateSettings(ctx context.Context, zone string, id types.ID, maxRetries int, mutator settingsMutator) (*iaas.VPCRouter, error) {
+	return updateSettings(ctx, iaas.NewVPCRouterOp(s.caller), zone, id, maxRetries, mutator)
+}
+
+func updateSettings(ctx context.Context, client settingsHandler, zone string, id types.ID, maxRetries int, mutator settingsMutator) (*iaas.VPCRouter, error) {
+	changed := false
+	read := func(ctx context.Context) (*iaas.VPCRouter, error) {
+		return client.Read(ctx, zone, id)
+	}
+	mutate := func(current *iaas.VPCRouter) error {
+		if current.Settings == nil {
+			current.Settings = &iaas.VPCRouterSetting{}
+		}
+		c, err := mutator(current)
+		changed = c
+		return err
+	}
+	update := func(ctx context.Context, current *iaas.VPCRouter) (*iaas.VPCRouter, error) {
+		if !changed {
+			return current, nil
+		}
+		updated, err := client.UpdateSettings(ctx, zone, id, &iaas.VPCRouterUpdateSettingsRequest{
+			Settings:     current.Settings,
+			SettingsHash: current.SettingsHash,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := client.Config(ctx, zone, id); err != nil {
+			return nil, err
+		}
+		return updated, nil
+	}
+	return serviceutil.UpdateWithMutator(ctx, maxRetries, read, mutate, update)
+}
+
+// addSettingEntry entriesにキーが同じエントリが存在しなければentryを追加する
+//
+// キーが同じエントリが存在し内容も同一の場合は変更なしとし、内容が異なる場合はエラーとする
+func addSettingEntry[T any](entries []T, entry T, kind string, key func(T) string, equal func(a, b T) bool) ([]T, bool, error) {
+	for _, e := range entries {
+		if key(e) == key(entry) {
+			if equal(e, entry) {
+				return entries, false, nil
+			}
+			return nil, false, fmt.Errorf("%s %q already exists with different settings", kind, key(entry))
+		}
+	}
+	return append(entries, entry), true, nil
+}
+
+// removeSettingEntry entriesからキーが一致するエントリを取り除く、存在しない場合は変更なしとする
+func removeSettingEntry[T any](entries []T, k string, key func(T) string) ([]T, bool) {
+	var result []T
+	changed := false
+	for _, e := range entries {
+		if key(e) == k {
+			changed = true
+			continue
+		}
+		result = append(result, e)
+	}
+	if !changed {
+		return entries, false
+	}
+	return result, true
+}
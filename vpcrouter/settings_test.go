@@ -0,0 +1,164 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+type conflictError struct{}
+
+func (conflictError) Error() string     { return "409 conflict" }
+func (conflictError) ResponseCode() int { return http.StatusConflict }
+
+// fakeSettingsHandler SettingsHashによる楽観的排他制御を模したsettingsHandler
+type fakeSettingsHandler struct {
+	settings     *iaas.VPCRouterSetting
+	settingsHash string
+	// conflictOnce trueの場合、初回のUpdateSettingsの前に他から更新されたものとしてコンフリクトさせる
+	conflictOnce bool
+	calls        []string
+}
+
+func (f *fakeSettingsHandler) Read(_ context.Context, _ string, id types.ID) (*iaas.VPCRouter, error) {
+	f.calls = append(f.calls, "Read")
+	settings := *f.settings
+	return &iaas.VPCRouter{ID: id, Settings: &settings, SettingsHash: f.settingsHash}, nil
+}
+
+func (f *fakeSettingsHandler) UpdateSettings(_ context.Context, _ string, id types.ID, param *iaas.VPCRouterUpdateSettingsRequest) (*iaas.VPCRouter, error) {
+	f.calls = append(f.calls, "UpdateSettings:"+param.SettingsHash)
+	if f.conflictOnce {
+		f.conflictOnce = false
+		f.settingsHash += "-updated"
+	}
+	if param.SettingsHash != f.settingsHash {
+		return nil, conflictError{}
+	}
+	f.settings = param.Settings
+	f.settingsHash += "-new"
+	return &iaas.VPCRouter{ID: id, Settings: f.settings, SettingsHash: f.settingsHash}, nil
+}
+
+func (f *fakeSettingsHandler) Config(context.Context, string, types.ID) error {
+	f.calls = append(f.calls, "Config")
+	return nil
+}
+
+func TestUpdateSettings(t *testing.T) {
+	route := &iaas.VPCRouterStaticRoute{Prefix: "10.0.1.0/24", NextHop: "192.168.0.3"}
+	mutator := func(current *iaas.VPCRouter) (bool, error) {
+		entries, changed, err := addSettingEntry(current.Settings.StaticRoute, route, "static route", staticRouteKey, func(a, b *iaas.VPCRouterStaticRoute) bool {
+			return *a == *b
+		})
+		current.Settings.StaticRoute = entries
+		return changed, err
+	}
+
+	t.Run("retry on conflict", func(t *testing.T) {
+		client := &fakeSettingsHandler{settings: &iaas.VPCRouterSetting{}, settingsHash: "hash", conflictOnce: true}
+
+		updated, err := updateSettings(context.Background(), client, "is1a", 1, 0, mutator)
+		require.NoError(t, err)
+		require.Equal(t, []*iaas.VPCRouterStaticRoute{route}, updated.Settings.StaticRoute)
+		// コンフリクト後は読み込みからやり直し、更新後のSettingsHashで更新してから設定を反映する
+		require.Equal(t, []string{
+			"Read",
+			"UpdateSettings:hash",
+			"Read",
+			"UpdateSettings:hash-updated",
+			"Config",
+		}, client.calls)
+	})
+
+	t.Run("no change", func(t *testing.T) {
+		client := &fakeSettingsHandler{
+			settings:     &iaas.VPCRouterSetting{StaticRoute: []*iaas.VPCRouterStaticRoute{route}},
+			settingsHash: "hash",
+		}
+
+		_, err := updateSettings(context.Background(), client, "is1a", 1, 0, mutator)
+		require.NoError(t, err)
+		require.Equal(t, []string{"Read"}, client.calls)
+	})
+
+	t.Run("max retries exceeded", func(t *testing.T) {
+		client := &alwaysConflictSettingsHandler{fakeSettingsHandler{settings: &iaas.VPCRouterSetting{}, settingsHash: "hash"}}
+
+		_, err := updateSettings(context.Background(), client, "is1a", 1, 2, mutator)
+		require.Error(t, err)
+		// 初回 + リトライ2回の読み込みと更新を行い、設定は反映しない
+		require.Len(t, client.calls, 6)
+		require.NotContains(t, client.calls, "Config")
+	})
+}
+
+type alwaysConflictSettingsHandler struct {
+	fakeSettingsHandler
+}
+
+func (f *alwaysConflictSettingsHandler) UpdateSettings(_ context.Context, _ string, _ types.ID, param *iaas.VPCRouterUpdateSettingsRequest) (*iaas.VPCRouter, error) {
+	f.calls = append(f.calls, "UpdateSettings:"+param.SettingsHash)
+	return nil, conflictError{}
+}
+
+func TestAddSettingEntry(t *testing.T) {
+	equal := func(a, b *iaas.VPCRouterStaticRoute) bool { return *a == *b }
+	routes := []*iaas.VPCRouterStaticRoute{
+		{Prefix: "10.0.0.0/24", NextHop: "192.168.0.2"},
+	}
+
+	added, changed, err := addSettingEntry(routes, &iaas.VPCRouterStaticRoute{Prefix: "10.0.1.0/24", NextHop: "192.168.0.3"}, "static route", staticRouteKey, equal)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Len(t, added, 2)
+
+	same, changed, err := addSettingEntry(routes, &iaas.VPCRouterStaticRoute{Prefix: "10.0.0.0/24", NextHop: "192.168.0.2"}, "static route", staticRouteKey, equal)
+	require.NoError(t, err)
+	require.False(t, changed)
+	require.Equal(t, routes, same)
+
+	_, _, err = addSettingEntry(routes, &iaas.VPCRouterStaticRoute{Prefix: "10.0.0.0/24", NextHop: "192.168.0.4"}, "static route", staticRouteKey, equal)
+	require.Error(t, err)
+}
+
+func TestRemoveSettingEntry(t *testing.T) {
+	users := []*iaas.VPCRouterRemoteAccessUser{
+		{UserName: "user1", Password: "password1"},
+		{UserName: "user2", Password: "password2"},
+	}
+
+	removed, changed := removeSettingEntry(users, "user1", remoteAccessUserKey)
+	require.True(t, changed)
+	require.Equal(t, []*iaas.VPCRouterRemoteAccessUser{users[1]}, removed)
+
+	removed, changed = removeSettingEntry(users, "user3", remoteAccessUserKey)
+	require.False(t, changed)
+	require.Equal(t, users, removed)
+}
+
+func TestSettingEntryKeys(t *testing.T) {
+	require.Equal(t, "tcp/8080", portForwardingKey(&iaas.VPCRouterPortForwarding{Protocol: "tcp", GlobalPort: 8080}))
+	require.Equal(t,
+		dhcpStaticMappingKey(&iaas.VPCRouterDHCPStaticMapping{MACAddress: "9C:A3:BA:00:00:01"}),
+		dhcpStaticMappingKey(&iaas.VPCRouterDHCPStaticMapping{MACAddress: "9c:a3:ba:00:00:01"}),
+	)
+}
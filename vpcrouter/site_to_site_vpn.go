@@ -0,0 +1,213 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+// サイト間VPNのパラメータが未指定の場合にVPCルータで利用される値
+const (
+	DefaultSiteToSiteVPNEncryptionAlgo = "aes128"
+	DefaultSiteToSiteVPNHashAlgo       = "sha1"
+	DefaultSiteToSiteVPNDHGroup        = "modp1024"
+	DefaultSiteToSiteVPNIKELifetime    = 28800
+	DefaultSiteToSiteVPNESPLifetime    = 1800
+	DefaultSiteToSiteVPNDPDInterval    = 15
+	DefaultSiteToSiteVPNDPDTimeout     = 30
+)
+
+// SiteToSiteVPNPeerConfig VPCルータとサイト間VPNを構成する対向機器側の設定
+//
+// Local/Remoteは対向機器から見た値となる
+type SiteToSiteVPNPeerConfig struct {
+	// Name 接続名
+	Name string
+	// RouterAddress VPCルータのグローバルIPアドレス、対向機器から見たリモートアドレス兼リモートID
+	RouterAddress string
+	// PeerAddress 対向機器のアドレス
+	PeerAddress string
+	// PeerID 対向機器のID
+	PeerID string
+	// PreSharedSecret 事前共有鍵
+	PreSharedSecret string
+	// LocalPrefixes 対向機器側のネットワーク(VPCルータのRoutes)
+	LocalPrefixes []string
+	// RemotePrefixes VPCルータ側のネットワーク(VPCルータのLocalPrefix)
+	RemotePrefixes []string
+
+	EncryptionAlgo string
+	HashAlgo       string
+	DHGroup        string
+	IKELifetime    int
+	ESPLifetime    int
+	DPDInterval    int
+	DPDTimeout     int
+}
+
+// Proposal strongSwan形式のプロポーザル(例: aes128-sha1-modp1024)
+func (c *SiteToSiteVPNPeerConfig) Proposal() string {
+	return strings.Join([]string{c.EncryptionAlgo, c.HashAlgo, c.DHGroup}, "-")
+}
+
+// siteToSiteVPNPeerConfigs VPCルータのサイト間VPN設定から対向機器側の設定を組み立てる
+//
+// peerが空でない場合は対向機器のアドレスがpeerと一致する設定のみを返す
+func siteToSiteVPNPeerConfigs(vpcRouter *iaas.VPCRouter, peer string) ([]*SiteToSiteVPNPeerConfig, error) {
+	if vpcRouter.Settings == nil || vpcRouter.Settings.SiteToSiteIPsecVPN == nil || len(vpcRouter.Settings.SiteToSiteIPsecVPN.Config) == 0 {
+		return nil, errors.New("site-to-site IPsec VPN is not configured on the VPC router")
+	}
+	routerAddress := globalIPAddress(vpcRouter)
+	if routerAddress == "" {
+		return nil, errors.New("global IP address of the VPC router is not found")
+	}
+
+	vpn := vpcRouter.Settings.SiteToSiteIPsecVPN
+	base := SiteToSiteVPNPeerConfig{
+		RouterAddress:  routerAddress,
+		EncryptionAlgo: stringOrDefault(vpn.EncryptionAlgo, DefaultSiteToSiteVPNEncryptionAlgo),
+		HashAlgo:       stringOrDefault(vpn.HashAlgo, DefaultSiteToSiteVPNHashAlgo),
+		DHGroup:        stringOrDefault(vpn.DHGroup, DefaultSiteToSiteVPNDHGroup),
+		IKELifetime:    DefaultSiteToSiteVPNIKELifetime,
+		ESPLifetime:    DefaultSiteToSiteVPNESPLifetime,
+		DPDInterval:    DefaultSiteToSiteVPNDPDInterval,
+		DPDTimeout:     DefaultSiteToSiteVPNDPDTimeout,
+	}
+	if vpn.IKE != nil {
+		base.IKELifetime = intOrDefault(vpn.IKE.Lifetime, base.IKELifetime)
+		if vpn.IKE.DPD != nil {
+			base.DPDInterval = intOrDefault(vpn.IKE.DPD.Interval, base.DPDInterval)
+			base.DPDTimeout = intOrDefault(vpn.IKE.DPD.Timeout, base.DPDTimeout)
+		}
+	}
+	if vpn.ESP != nil {
+		base.ESPLifetime = intOrDefault(vpn.ESP.Lifetime, base.ESPLifetime)
+	}
+
+	var configs []*SiteToSiteVPNPeerConfig
+	for i, c := range vpn.Config {
+		if peer != "" && c.Peer != peer {
+			continue
+		}
+		config := base
+		config.Name = fmt.Sprintf("vpcrouter-%s-%d", vpcRouter.ID, i+1)
+		config.PeerAddress = c.Peer
+		config.PeerID = stringOrDefault(c.RemoteID, c.Peer)
+		config.PreSharedSecret = c.PreSharedSecret
+		config.LocalPrefixes = c.Routes
+		config.RemotePrefixes = c.LocalPrefix
+		configs = append(configs, &config)
+	}
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("site-to-site IPsec VPN peer %q not found", peer)
+	}
+	return configs, nil
+}
+
+// FormatSwanctl 対向機器向けにstrongSwanのswanctl.conf形式で出力する
+func FormatSwanctl(configs []*SiteToSiteVPNPeerConfig) string {
+	var sb strings.Builder
+	sb.WriteString("connections {\n")
+	for _, c := range configs {
+		fmt.Fprintf(&sb, "    %s {\n", c.Name)
+		sb.WriteString("        version = 1\n")
+		fmt.Fprintf(&sb, "        local_addrs = %s\n", c.PeerAddress)
+		fmt.Fprintf(&sb, "        remote_addrs = %s\n", c.RouterAddress)
+		fmt.Fprintf(&sb, "        proposals = %s\n", c.Proposal())
+		fmt.Fprintf(&sb, "        rekey_time = %ds\n", c.IKELifetime)
+		fmt.Fprintf(&sb, "        dpd_delay = %ds\n", c.DPDInterval)
+		fmt.Fprintf(&sb, "        dpd_timeout = %ds\n", c.DPDTimeout)
+		sb.WriteString("        local {\n")
+		sb.WriteString("            auth = psk\n")
+		fmt.Fprintf(&sb, "            id = %s\n", c.PeerID)
+		sb.WriteString("        }\n")
+		sb.WriteString("        remote {\n")
+		sb.WriteString("            auth = psk\n")
+		fmt.Fprintf(&sb, "            id = %s\n", c.RouterAddress)
+		sb.WriteString("        }\n")
+		sb.WriteString("        children {\n")
+		fmt.Fprintf(&sb, "            %s {\n", c.Name)
+		fmt.Fprintf(&sb, "                local_ts = %s\n", strings.Join(c.LocalPrefixes, ","))
+		fmt.Fprintf(&sb, "                remote_ts = %s\n", strings.Join(c.RemotePrefixes, ","))
+		fmt.Fprintf(&sb, "                esp_proposals = %s\n", c.Proposal())
+		fmt.Fprintf(&sb, "                rekey_time = %ds\n", c.ESPLifetime)
+		sb.WriteString("                dpd_action = restart\n")
+		sb.WriteString("                start_action = start\n")
+		sb.WriteString("            }\n")
+		sb.WriteString("        }\n")
+		sb.WriteString("    }\n")
+	}
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("secrets {\n")
+	for _, c := range configs {
+		fmt.Fprintf(&sb, "    ike-%s {\n", c.Name)
+		fmt.Fprintf(&sb, "        id-1 = %s\n", c.PeerID)
+		fmt.Fprintf(&sb, "        id-2 = %s\n", c.RouterAddress)
+		fmt.Fprintf(&sb, "        secret = %s\n", strconv.Quote(c.PreSharedSecret))
+		sb.WriteString("    }\n")
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// FormatSiteToSiteVPNSummary 対向機器向けの設定を機器に依存しないkey=value形式で出力する
+func FormatSiteToSiteVPNSummary(configs []*SiteToSiteVPNPeerConfig) string {
+	var sb strings.Builder
+	for i, c := range configs {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "[%s]\n", c.Name)
+		values := []struct{ key, value string }{
+			{"router_address", c.RouterAddress},
+			{"peer_address", c.PeerAddress},
+			{"peer_id", c.PeerID},
+			{"pre_shared_key", c.PreSharedSecret},
+			{"ike_version", "1"},
+			{"ike_proposal", c.Proposal()},
+			{"ike_lifetime", strconv.Itoa(c.IKELifetime)},
+			{"esp_proposal", c.Proposal()},
+			{"esp_lifetime", strconv.Itoa(c.ESPLifetime)},
+			{"dpd_interval", strconv.Itoa(c.DPDInterval)},
+			{"dpd_timeout", strconv.Itoa(c.DPDTimeout)},
+			{"local_prefixes", strings.Join(c.LocalPrefixes, ",")},
+			{"remote_prefixes", strings.Join(c.RemotePrefixes, ",")},
+		}
+		for _, v := range values {
+			fmt.Fprintf(&sb, "%s = %s\n", v.key, v.value)
+		}
+	}
+	return sb.String()
+}
+
+func stringOrDefault(v, defaultValue string) string {
+	if v == "" {
+		return defaultValue
+	}
+	return v
+}
+
+func intOrDefault(v, defaultValue int) int {
+	if v == 0 {
+		return defaultValue
+	}
+	return v
+}
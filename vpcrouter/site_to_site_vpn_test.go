@@ -0,0 +1,154 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func testSiteToSiteVPNRouter() *iaas.VPCRouter {
+	return &iaas.VPCRouter{
+		ID:     types.ID(123456789012),
+		PlanID: types.VPCRouterPlans.Standard,
+		Interfaces: []*iaas.VPCRouterInterface{
+			{Index: 0, IPAddress: "203.0.113.10"},
+		},
+		Settings: &iaas.VPCRouterSetting{
+			SiteToSiteIPsecVPN: &iaas.VPCRouterSiteToSiteIPsecVPN{
+				Config: []*iaas.VPCRouterSiteToSiteIPsecVPNConfig{
+					{
+						Peer:            "198.51.100.1",
+						RemoteID:        "198.51.100.1",
+						PreSharedSecret: "secret1",
+						Routes:          []string{"10.0.0.0/24"},
+						LocalPrefix:     []string{"192.168.0.0/24"},
+					},
+					{
+						Peer:            "198.51.100.2",
+						PreSharedSecret: "secret2",
+						Routes:          []string{"10.0.1.0/24", "10.0.2.0/24"},
+						LocalPrefix:     []string{"192.168.0.0/24"},
+					},
+				},
+				IKE: &iaas.VPCRouterSiteToSiteIPsecVPNIKE{
+					Lifetime: 3600,
+					DPD:      &iaas.VPCRouterSiteToSiteIPsecVPNIKEDPD{Interval: 10, Timeout: 20},
+				},
+				EncryptionAlgo: "aes256",
+				HashAlgo:       "sha256",
+			},
+		},
+	}
+}
+
+func TestSiteToSiteVPNPeerConfigs(t *testing.T) {
+	configs, err := siteToSiteVPNPeerConfigs(testSiteToSiteVPNRouter(), "")
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+
+	require.Equal(t, &SiteToSiteVPNPeerConfig{
+		Name:            "vpcrouter-123456789012-2",
+		RouterAddress:   "203.0.113.10",
+		PeerAddress:     "198.51.100.2",
+		PeerID:          "198.51.100.2",
+		PreSharedSecret: "secret2",
+		LocalPrefixes:   []string{"10.0.1.0/24", "10.0.2.0/24"},
+		RemotePrefixes:  []string{"192.168.0.0/24"},
+		EncryptionAlgo:  "aes256",
+		HashAlgo:        "sha256",
+		DHGroup:         DefaultSiteToSiteVPNDHGroup,
+		IKELifetime:     3600,
+		ESPLifetime:     DefaultSiteToSiteVPNESPLifetime,
+		DPDInterval:     10,
+		DPDTimeout:      20,
+	}, configs[1])
+
+	configs, err = siteToSiteVPNPeerConfigs(testSiteToSiteVPNRouter(), "198.51.100.1")
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	require.Equal(t, "vpcrouter-123456789012-1", configs[0].Name)
+
+	_, err = siteToSiteVPNPeerConfigs(testSiteToSiteVPNRouter(), "198.51.100.3")
+	require.Error(t, err)
+
+	_, err = siteToSiteVPNPeerConfigs(&iaas.VPCRouter{Settings: &iaas.VPCRouterSetting{}}, "")
+	require.Error(t, err)
+}
+
+func TestFormatSiteToSiteVPN(t *testing.T) {
+	configs, err := siteToSiteVPNPeerConfigs(testSiteToSiteVPNRouter(), "198.51.100.1")
+	require.NoError(t, err)
+
+	expectedSwanctl := `connections {
+    vpcrouter-123456789012-1 {
+        version = 1
+        local_addrs = 198.51.100.1
+        remote_addrs = 203.0.113.10
+        proposals = aes256-sha256-modp1024
+        rekey_time = 3600s
+        dpd_delay = 10s
+        dpd_timeout = 20s
+        local {
+            auth = psk
+            id = 198.51.100.1
+        }
+        remote {
+            auth = psk
+            id = 203.0.113.10
+        }
+        children {
+            vpcrouter-123456789012-1 {
+                local_ts = 10.0.0.0/24
+                remote_ts = 192.168.0.0/24
+                esp_proposals = aes256-sha256-modp1024
+                rekey_time = 1800s
+                dpd_action = restart
+                start_action = start
+            }
+        }
+    }
+}
+
+secrets {
+    ike-vpcrouter-123456789012-1 {
+        id-1 = 198.51.100.1
+        id-2 = 203.0.113.10
+        secret = "secret1"
+    }
+}
+`
+	require.Equal(t, expectedSwanctl, FormatSwanctl(configs))
+
+	expectedSummary := `[vpcrouter-123456789012-1]
+router_address = 203.0.113.10
+peer_address = 198.51.100.1
+peer_id = 198.51.100.1
+pre_shared_key = secret1
+ike_version = 1
+ike_proposal = aes256-sha256-modp1024
+ike_lifetime = 3600
+esp_proposal = aes256-sha256-modp1024
+esp_lifetime = 1800
+dpd_interval = 10
+dpd_timeout = 20
+local_prefixes = 10.0.0.0/24
+remote_prefixes = 192.168.0.0/24
+`
+	require.Equal(t, expectedSummary, FormatSiteToSiteVPNSummary(configs))
+}
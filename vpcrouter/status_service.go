@@ -0,0 +1,106 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"context"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+// Status VPCルータのステータス
+type Status struct {
+	SessionCount       int
+	WireGuardPublicKey string
+
+	DHCPServerLeases        []*iaas.VPCRouterDHCPServerLease
+	L2TPIPsecServerSessions []*iaas.VPCRouterL2TPIPsecServerSession
+	PPTPServerSessions      []*iaas.VPCRouterPPTPServerSession
+	SiteToSiteIPsecVPNPeers []*iaas.VPCRouterSiteToSiteIPsecVPNPeer
+	// WireGuardPeers VPCルータの設定に登録されているWireGuardのピア
+	WireGuardPeers []*iaas.VPCRouterWireGuardPeer
+
+	// Logs ステータスに含まれるVPNとファイアウォールのログ
+	Logs []*LogEntry
+}
+
+func (s *Service) Status(req *StatusRequest) (*Status, error) {
+	return s.StatusWithContext(context.Background(), req)
+}
+
+func (s *Service) StatusWithContext(ctx context.Context, req *StatusRequest) (*Status, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	client := iaas.NewVPCRouterOp(s.caller)
+	vpcRouter, err := client.Read(ctx, req.Zone, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	status, err := client.Status(ctx, req.Zone, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// ピアの一覧はステータスに含まれないため設定から取得する
+	var peers []*iaas.VPCRouterWireGuardPeer
+	if vpcRouter.Settings != nil && vpcRouter.Settings.WireGuard != nil {
+		peers = vpcRouter.Settings.WireGuard.Peers
+	}
+	return filterStatus(status, peers, &req.LogFilter, time.Now()), nil
+}
+
+func filterStatus(status *iaas.VPCRouterStatus, wireGuardPeers []*iaas.VPCRouterWireGuardPeer, filter *LogFilter, now time.Time) *Status {
+	result := &Status{SessionCount: status.SessionCount}
+	if status.WireGuard != nil {
+		result.WireGuardPublicKey = status.WireGuard.PublicKey
+	}
+
+	for _, lease := range status.DHCPServerLeases {
+		if containsFold(lease.IPAddress, filter.Contains) || containsFold(lease.MACAddress, filter.Contains) {
+			result.DHCPServerLeases = append(result.DHCPServerLeases, lease)
+		}
+	}
+	for _, session := range status.L2TPIPsecServerSessions {
+		if containsFold(session.User, filter.Contains) || containsFold(session.IPAddress, filter.Contains) {
+			result.L2TPIPsecServerSessions = append(result.L2TPIPsecServerSessions, session)
+		}
+	}
+	for _, session := range status.PPTPServerSessions {
+		if containsFold(session.User, filter.Contains) || containsFold(session.IPAddress, filter.Contains) {
+			result.PPTPServerSessions = append(result.PPTPServerSessions, session)
+		}
+	}
+	for _, peer := range status.SiteToSiteIPsecVPNPeers {
+		if containsFold(peer.Peer, filter.Contains) || containsFold(peer.Status, filter.Contains) {
+			result.SiteToSiteIPsecVPNPeers = append(result.SiteToSiteIPsecVPNPeers, peer)
+		}
+	}
+	for _, peer := range wireGuardPeers {
+		if containsFold(peer.Name, filter.Contains) || containsFold(peer.IPAddress, filter.Contains) || containsFold(peer.PublicKey, filter.Contains) {
+			result.WireGuardPeers = append(result.WireGuardPeers, peer)
+		}
+	}
+
+	var logs []*LogEntry
+	loc := filter.location()
+	logs = append(logs, parseLogs(status.VPNLogs, "", now, loc)...)
+	logs = append(logs, parseLogs(status.FirewallSendLogs, LogKinds.Firewall, now, loc)...)
+	logs = append(logs, parseLogs(status.FirewallReceiveLogs, LogKinds.Firewall, now, loc)...)
+	result.Logs = filter.Filter(logs)
+	return result
+}
@@ -0,0 +1,48 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/packages-go/validate"
+)
+
+// SyncDHCPStaticMappingsRequest VPCルータのインターフェースに接続されたサーバからDHCPスタティックマッピングを同期するためのリクエスト
+type SyncDHCPStaticMappingsRequest struct {
+	Zone string   `validate:"required"`
+	ID   types.ID `validate:"required"`
+
+	// Index 対象のインターフェースのインデックス
+	Index int `validate:"min=1,max=7"`
+	// SwitchID 対象のスイッチのID、空の場合はIndexのインターフェースに接続されたスイッチを利用する
+	SwitchID types.ID
+
+	// Addresses サーバのホスト名または名前をキーとした割り当てるアドレス
+	Addresses map[string]string `validate:"omitempty,dive,ipv4"`
+	// TagPrefix Addressesで決定できない場合に参照するタグのプレフィックス、空の場合はDefaultDHCPStaticMappingTagPrefix
+	TagPrefix string
+
+	// Prune インターフェースのネットワーク内のマッピングのうち、対象のサーバのNICに該当しないものを削除する
+	Prune bool
+	// DryRun trueの場合は同期結果の算出のみ行い、VPCルータを更新しない
+	DryRun bool
+
+	// MaxRetries コンフリクト時の最大リトライ回数、0の場合はserviceutil.DefaultConflictRetryCountを利用する
+	MaxRetries int `validate:"min=0"`
+}
+
+func (req *SyncDHCPStaticMappingsRequest) Validate() error {
+	return validate.New().Struct(req)
+}
@@ -0,0 +1,72 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+func (s *Service) SyncDHCPStaticMappings(req *SyncDHCPStaticMappingsRequest) (*DHCPStaticMappingSyncResult, error) {
+	return s.SyncDHCPStaticMappingsWithContext(context.Background(), req)
+}
+
+func (s *Service) SyncDHCPStaticMappingsWithContext(ctx context.Context, req *SyncDHCPStaticMappingsRequest) (*DHCPStaticMappingSyncResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	policy := &DHCPStaticMappingPolicy{Addresses: req.Addresses, TagPrefix: req.TagPrefix}
+	serversBySwitch := map[types.ID][]*iaas.Server{}
+
+	var result *DHCPStaticMappingSyncResult
+	_, err := s.updateSettings(ctx, req.Zone, req.ID, req.MaxRetries, func(current *iaas.VPCRouter) (bool, error) {
+		network, routerAddresses, err := dhcpInterface(current, req.Index)
+		if err != nil {
+			return false, err
+		}
+		switchID := req.SwitchID
+		if switchID.IsEmpty() {
+			switchID, err = connectedSwitchID(current, req.Index)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		// リトライ時はサーバの一覧を再取得しない
+		servers, ok := serversBySwitch[switchID]
+		if !ok {
+			found, err := iaas.NewSwitchOp(s.caller).GetServers(ctx, req.Zone, switchID)
+			if err != nil {
+				return false, err
+			}
+			servers = found.Servers
+			serversBySwitch[switchID] = servers
+		}
+
+		result = planDHCPStaticMappings(current.Settings.DHCPStaticMapping, servers, switchID, network, routerAddresses, policy, req.Prune)
+		if req.DryRun || !result.Changed() {
+			return false, nil
+		}
+		current.Settings.DHCPStaticMapping = result.Mappings
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
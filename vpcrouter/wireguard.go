@@ -0,0 +1,228 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"golang.org/x/crypto/curve25519"
+)
+
+// WireGuardPort VPCルータのWireGuardサーバが待ち受けるUDPポート
+const WireGuardPort = 51820
+
+// WireGuardKeyPair WireGuardの鍵ペア、いずれもbase64エンコードされた32バイトの鍵
+type WireGuardKeyPair struct {
+	PrivateKey string
+	PublicKey  string
+}
+
+// GenerateWireGuardKeyPair Curve25519の鍵ペアをローカルで生成する
+func GenerateWireGuardKeyPair() (*WireGuardKeyPair, error) {
+	var privateKey [curve25519.ScalarSize]byte
+	if _, err := rand.Read(privateKey[:]); err != nil {
+		return nil, err
+	}
+	// wg genkeyと同様にclampしておく
+	privateKey[0] &= 248
+	privateKey[31] = (privateKey[31] & 127) | 64
+
+	publicKey, err := curve25519.X25519(privateKey[:], curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	return &WireGuardKeyPair{
+		PrivateKey: base64.StdEncoding.EncodeToString(privateKey[:]),
+		PublicKey:  base64.StdEncoding.EncodeToString(publicKey),
+	}, nil
+}
+
+// WireGuardPublicKey base64エンコードされた秘密鍵から公開鍵を算出する
+func WireGuardPublicKey(privateKey string) (string, error) {
+	key, err := decodeWireGuardKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	publicKey, err := curve25519.X25519(key, curve25519.Basepoint)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(publicKey), nil
+}
+
+func decodeWireGuardKey(key string) ([]byte, error) {
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WireGuard key: %w", err)
+	}
+	if len(decoded) != curve25519.ScalarSize {
+		return nil, fmt.Errorf("invalid WireGuard key: got %d bytes, want %d", len(decoded), curve25519.ScalarSize)
+	}
+	return decoded, nil
+}
+
+// WireGuardClientConfig wg-quickで利用するクライアント(ピア)側の設定
+type WireGuardClientConfig struct {
+	// PrivateKey ピアの秘密鍵
+	PrivateKey string
+	// Address ピアに割り当てられたアドレス(CIDR表記)
+	Address string
+	// DNS ピアで利用するDNSサーバ
+	DNS []string
+
+	// ServerPublicKey VPCルータのWireGuard公開鍵
+	ServerPublicKey string
+	// Endpoint VPCルータのWireGuardサーバのエンドポイント(host:port)
+	Endpoint string
+	// AllowedIPs VPCルータ経由で通信するネットワーク
+	AllowedIPs []string
+	// PersistentKeepalive キープアライブの送信間隔(秒)、0の場合は出力しない
+	PersistentKeepalive int
+}
+
+// String wg-quick形式の設定ファイルとして出力する
+func (c *WireGuardClientConfig) String() string {
+	var sb strings.Builder
+	sb.WriteString("[Interface]\n")
+	sb.WriteString("PrivateKey = " + c.PrivateKey + "\n")
+	sb.WriteString("Address = " + c.Address + "\n")
+	if len(c.DNS) > 0 {
+		sb.WriteString("DNS = " + strings.Join(c.DNS, ", ") + "\n")
+	}
+	sb.WriteString("\n[Peer]\n")
+	sb.WriteString("PublicKey = " + c.ServerPublicKey + "\n")
+	sb.WriteString("Endpoint = " + c.Endpoint + "\n")
+	sb.WriteString("AllowedIPs = " + strings.Join(c.AllowedIPs, ", ") + "\n")
+	if c.PersistentKeepalive > 0 {
+		sb.WriteString("PersistentKeepalive = " + strconv.Itoa(c.PersistentKeepalive) + "\n")
+	}
+	return sb.String()
+}
+
+// wireGuardPrefix WireGuardサーバのアドレス(例: 192.168.31.1/24)からサーバアドレスとネットワークを返す
+func wireGuardPrefix(wg *iaas.VPCRouterWireGuard) (netip.Addr, netip.Prefix, error) {
+	if wg == nil || wg.IPAddress == "" {
+		return netip.Addr{}, netip.Prefix{}, errors.New("WireGuard server is not configured on the VPC router")
+	}
+	prefix, err := netip.ParsePrefix(wg.IPAddress)
+	if err != nil {
+		return netip.Addr{}, netip.Prefix{}, fmt.Errorf("invalid WireGuard IP address %q: %w", wg.IPAddress, err)
+	}
+	if !prefix.Addr().Is4() {
+		return netip.Addr{}, netip.Prefix{}, fmt.Errorf("invalid WireGuard IP address %q: IPv4 address is required", wg.IPAddress)
+	}
+	return prefix.Addr(), prefix.Masked(), nil
+}
+
+// parsePeerAddress ピアのアドレスをパースする、CIDR表記の場合はアドレス部分のみを返す
+func parsePeerAddress(address string) (netip.Addr, error) {
+	if prefix, err := netip.ParsePrefix(address); err == nil {
+		return prefix.Addr(), nil
+	}
+	return netip.ParseAddr(address)
+}
+
+// nextWireGuardPeerAddress WireGuardのアドレス範囲からサーバや既存ピアが利用していない最初のアドレスを返す
+func nextWireGuardPeerAddress(wg *iaas.VPCRouterWireGuard) (string, error) {
+	server, network, err := wireGuardPrefix(wg)
+	if err != nil {
+		return "", err
+	}
+
+	used := map[netip.Addr]bool{server: true}
+	for _, peer := range wg.Peers {
+		if addr, err := parsePeerAddress(peer.IPAddress); err == nil {
+			used[addr] = true
+		}
+	}
+
+	// ネットワークアドレスとブロードキャストアドレスは除外する
+	for addr := network.Addr().Next(); network.Contains(addr); addr = addr.Next() {
+		if !network.Contains(addr.Next()) {
+			break
+		}
+		if !used[addr] {
+			return addr.String(), nil
+		}
+	}
+	return "", fmt.Errorf("no free address in WireGuard network %s", network)
+}
+
+// validateWireGuardPeerAddress ピアのアドレスがWireGuardのアドレス範囲内でありサーバや他のピアと重複していないか検証する
+func validateWireGuardPeerAddress(wg *iaas.VPCRouterWireGuard, name, address string) error {
+	server, network, err := wireGuardPrefix(wg)
+	if err != nil {
+		return err
+	}
+	addr, err := parsePeerAddress(address)
+	if err != nil {
+		return fmt.Errorf("invalid peer IP address %q: %w", address, err)
+	}
+	if !network.Contains(addr) {
+		return fmt.Errorf("peer IP address %s is out of WireGuard network %s", addr, network)
+	}
+	if addr == server {
+		return fmt.Errorf("peer IP address %s is used by the VPC router", addr)
+	}
+	for _, peer := range wg.Peers {
+		if peer.Name == name {
+			continue
+		}
+		if used, err := parsePeerAddress(peer.IPAddress); err == nil && used == addr {
+			return fmt.Errorf("peer IP address %s is already used by peer %q", addr, peer.Name)
+		}
+	}
+	return nil
+}
+
+func findWireGuardPeer(wg *iaas.VPCRouterWireGuard, name string) *iaas.VPCRouterWireGuardPeer {
+	if wg == nil {
+		return nil
+	}
+	for _, peer := range wg.Peers {
+		if peer.Name == name {
+			return peer
+		}
+	}
+	return nil
+}
+
+// globalIPAddress VPCルータのグローバル側(eth0)のアドレスを返す
+func globalIPAddress(vpcRouter *iaas.VPCRouter) string {
+	if vpcRouter.PlanID == types.VPCRouterPlans.Standard {
+		for _, iface := range vpcRouter.Interfaces {
+			if iface.Index == 0 {
+				return iface.IPAddress
+			}
+		}
+		return ""
+	}
+	if vpcRouter.Settings != nil {
+		for _, iface := range vpcRouter.Settings.Interfaces {
+			if iface.Index == 0 {
+				return iface.VirtualIPAddress
+			}
+		}
+	}
+	return ""
+}
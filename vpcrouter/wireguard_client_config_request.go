@@ -0,0 +1,44 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/packages-go/validate"
+)
+
+// WireGuardClientConfigRequest WireGuardのピア向けにwg-quickの設定を生成するためのリクエスト
+type WireGuardClientConfigRequest struct {
+	Zone string   `validate:"required"`
+	ID   types.ID `validate:"required"`
+
+	// PeerName VPCルータに登録済みのピアの名前
+	PeerName string `validate:"required"`
+	// PrivateKey ピアの秘密鍵、登録済みの公開鍵と対応している必要がある
+	PrivateKey string `validate:"required"`
+
+	// AllowedIPs VPCルータ経由で通信するネットワーク、空の場合はWireGuardのネットワークのみとする
+	AllowedIPs []string `validate:"omitempty,dive,cidrv4"`
+	// DNS ピアで利用するDNSサーバ
+	DNS []string `validate:"omitempty,dive,ip"`
+	// Endpoint VPCルータのエンドポイント(host:port)、空の場合はVPCルータのグローバルIPとWireGuardPortを利用する
+	Endpoint string `validate:"omitempty,hostname_port"`
+	// PersistentKeepalive キープアライブの送信間隔(秒)
+	PersistentKeepalive int `validate:"min=0,max=65535"`
+}
+
+func (req *WireGuardClientConfigRequest) Validate() error {
+	return validate.New().Struct(req)
+}
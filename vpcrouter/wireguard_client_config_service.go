@@ -0,0 +1,96 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+func (s *Service) WireGuardClientConfig(req *WireGuardClientConfigRequest) (*WireGuardClientConfig, error) {
+	return s.WireGuardClientConfigWithContext(context.Background(), req)
+}
+
+func (s *Service) WireGuardClientConfigWithContext(ctx context.Context, req *WireGuardClientConfigRequest) (*WireGuardClientConfig, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	client := iaas.NewVPCRouterOp(s.caller)
+	vpcRouter, err := client.Read(ctx, req.Zone, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if vpcRouter.Settings == nil {
+		return nil, errors.New("WireGuard server is not configured on the VPC router")
+	}
+	wg := vpcRouter.Settings.WireGuard
+	_, network, err := wireGuardPrefix(wg)
+	if err != nil {
+		return nil, err
+	}
+
+	peer := findWireGuardPeer(wg, req.PeerName)
+	if peer == nil {
+		return nil, fmt.Errorf("WireGuard peer %q not found", req.PeerName)
+	}
+	publicKey, err := WireGuardPublicKey(req.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	if publicKey != peer.PublicKey {
+		return nil, fmt.Errorf("private key does not match the public key of WireGuard peer %q", req.PeerName)
+	}
+	address, err := parsePeerAddress(peer.IPAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IP address of WireGuard peer %q: %w", req.PeerName, err)
+	}
+
+	status, err := client.Status(ctx, req.Zone, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if status.WireGuard == nil || status.WireGuard.PublicKey == "" {
+		return nil, errors.New("public key of the WireGuard server is not available yet")
+	}
+
+	endpoint := req.Endpoint
+	if endpoint == "" {
+		globalIP := globalIPAddress(vpcRouter)
+		if globalIP == "" {
+			return nil, errors.New("global IP address of the VPC router is not found")
+		}
+		endpoint = net.JoinHostPort(globalIP, strconv.Itoa(WireGuardPort))
+	}
+	allowedIPs := req.AllowedIPs
+	if len(allowedIPs) == 0 {
+		allowedIPs = []string{network.String()}
+	}
+
+	return &WireGuardClientConfig{
+		PrivateKey:          req.PrivateKey,
+		Address:             address.String() + "/32",
+		DNS:                 req.DNS,
+		ServerPublicKey:     status.WireGuard.PublicKey,
+		Endpoint:            endpoint,
+		AllowedIPs:          allowedIPs,
+		PersistentKeepalive: req.PersistentKeepalive,
+	}, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWireGuardKeyPair(t *testing.T) {
+	kp, err := GenerateWireGuardKeyPair()
+	require.NoError(t, err)
+
+	publicKey, err := WireGuardPublicKey(kp.PrivateKey)
+	require.NoError(t, err)
+	require.Equal(t, kp.PublicKey, publicKey)
+
+	// RFC 7748 6.1
+	privateKey, _ := hex.DecodeString("77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a")
+	expected, _ := hex.DecodeString("8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a")
+	publicKey, err = WireGuardPublicKey(base64.StdEncoding.EncodeToString(privateKey))
+	require.NoError(t, err)
+	require.Equal(t, base64.StdEncoding.EncodeToString(expected), publicKey)
+
+	_, err = WireGuardPublicKey("invalid")
+	require.Error(t, err)
+}
+
+func TestNextWireGuardPeerAddress(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      *iaas.VPCRouterWireGuard
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "not configured",
+			in:      nil,
+			wantErr: true,
+		},
+		{
+			name: "first address is used by server",
+			in:   &iaas.VPCRouterWireGuard{IPAddress: "192.168.31.1/24"},
+			want: "192.168.31.2",
+		},
+		{
+			name: "skip used addresses",
+			in: &iaas.VPCRouterWireGuard{
+				IPAddress: "192.168.31.2/24",
+				Peers: []*iaas.VPCRouterWireGuardPeer{
+					{Name: "peer1", IPAddress: "192.168.31.1"},
+					{Name: "peer2", IPAddress: "192.168.31.3/32"},
+				},
+			},
+			want: "192.168.31.4",
+		},
+		{
+			name: "exhausted",
+			in: &iaas.VPCRouterWireGuard{
+				IPAddress: "192.168.31.1/30",
+				Peers: []*iaas.VPCRouterWireGuardPeer{
+					{Name: "peer1", IPAddress: "192.168.31.2"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := nextWireGuardPeerAddress(tc.in)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestValidateWireGuardPeerAddress(t *testing.T) {
+	wg := &iaas.VPCRouterWireGuard{
+		IPAddress: "192.168.31.1/24",
+		Peers: []*iaas.VPCRouterWireGuardPeer{
+			{Name: "peer1", IPAddress: "192.168.31.2"},
+		},
+	}
+
+	require.NoError(t, validateWireGuardPeerAddress(wg, "peer2", "192.168.31.3"))
+	require.NoError(t, validateWireGuardPeerAddress(wg, "peer1", "192.168.31.2"))
+	require.Error(t, validateWireGuardPeerAddress(wg, "peer2", "192.168.31.1"))
+	require.Error(t, validateWireGuardPeerAddress(wg, "peer2", "192.168.31.2"))
+	require.Error(t, validateWireGuardPeerAddress(wg, "peer2", "192.168.32.2"))
+}
+
+func TestGlobalIPAddress(t *testing.T) {
+	standard := &iaas.VPCRouter{
+		PlanID: types.VPCRouterPlans.Standard,
+		Interfaces: []*iaas.VPCRouterInterface{
+			{Index: 0, IPAddress: "203.0.113.10"},
+		},
+	}
+	require.Equal(t, "203.0.113.10", globalIPAddress(standard))
+
+	premium := &iaas.VPCRouter{
+		PlanID: types.VPCRouterPlans.Premium,
+		Settings: &iaas.VPCRouterSetting{
+			Interfaces: []*iaas.VPCRouterInterfaceSetting{
+				{Index: 1, VirtualIPAddress: "192.168.0.1"},
+				{Index: 0, VirtualIPAddress: "203.0.113.20"},
+			},
+		},
+	}
+	require.Equal(t, "203.0.113.20", globalIPAddress(premium))
+}
+
+func TestWireGuardClientConfig_String(t *testing.T) {
+	config := &WireGuardClientConfig{
+		PrivateKey:          "client-private-key",
+		Address:             "192.168.31.2/32",
+		DNS:                 []string{"133.242.0.3", "133.242.0.4"},
+		ServerPublicKey:     "server-public-key",
+		Endpoint:            "203.0.113.10:51820",
+		AllowedIPs:          []string{"192.168.31.0/24", "192.168.0.0/24"},
+		PersistentKeepalive: 25,
+	}
+	expected := `[Interface]
+PrivateKey = client-private-key
+Address = 192.168.31.2/32
+DNS = 133.242.0.3, 133.242.0.4
+
+[Peer]
+PublicKey = server-public-key
+Endpoint = 203.0.113.10:51820
+AllowedIPs = 192.168.31.0/24, 192.168.0.0/24
+PersistentKeepalive = 25
+`
+	require.Equal(t, expected, config.String())
+}